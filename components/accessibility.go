@@ -0,0 +1,278 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file has the accessibility options. The colour-blind palettes swap a theme's number and AI colours for ones that
+stay apart with red-green (Okabe-Ito) or blue-yellow (tritan) colour blindness. Cells the AI revealed can also get a
+corner marker so they do not rely on colour at all. Every cell and the game result have a plain text description which
+is shown under the board, printed by the terminal front-end and, when turned on, spoken out loud with the system's
+text-to-speech command so the game can be followed without seeing the board
+
+Functions:
+- PaletteNames: Names of the palettes in the order the Settings screen lists them
+
+- withPalette: Gives a copy of a theme using a colour-blind palette
+
+- describeCell: Plain text description of one cell, e.g. "c4, 3 mines nearby, revealed by the AI"
+
+- describeResult: Plain text description of how the game ended
+
+- speak: Reads a message out loud if speech is turned on in the settings
+
+Inputs:
+- Board theme, game handler and the accessibility settings
+
+Outputs:
+- Colours for the board and descriptions for screen readers/speech
+*/
+
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"minesweeper/config"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Palette names, also what is stored in the settings file
+const (
+	PaletteTheme    = "theme"     // The theme's own colours
+	PaletteOkabeIto = "okabe-ito" // Safe for red-green colour blindness
+	PaletteTritan   = "tritan"    // Safe for blue-yellow colour blindness
+)
+
+// Number colours (index 0 unused) and AI colour of a palette, one set for light boards and one for dark boards
+type palette struct {
+	light, dark     [9]color.Color
+	lightAI, darkAI color.Color
+}
+
+var palettes = map[string]palette{
+	PaletteOkabeIto: {
+		light: [9]color.Color{nil,
+			color.NRGBA{R: 0, G: 114, B: 178, A: 255}, // blue
+			color.NRGBA{R: 0, G: 120, B: 87, A: 255},  // bluish green
+			color.NRGBA{R: 213, G: 94, B: 0, A: 255},  // vermillion
+			color.NRGBA{R: 90, G: 40, B: 120, A: 255}, // purple
+			color.NRGBA{R: 150, G: 90, B: 0, A: 255},  // dark orange
+			color.NRGBA{R: 0, G: 80, B: 130, A: 255},  // dark blue
+			color.NRGBA{R: 0, G: 0, B: 0, A: 255},     // black
+			color.NRGBA{R: 80, G: 80, B: 80, A: 255},  // dark grey
+		},
+		dark: [9]color.Color{nil,
+			color.NRGBA{R: 86, G: 180, B: 233, A: 255},  // sky blue
+			color.NRGBA{R: 0, G: 158, B: 115, A: 255},   // bluish green
+			color.NRGBA{R: 230, G: 159, B: 0, A: 255},   // orange
+			color.NRGBA{R: 204, G: 121, B: 167, A: 255}, // reddish purple
+			color.NRGBA{R: 240, G: 228, B: 66, A: 255},  // yellow
+			color.NRGBA{R: 150, G: 210, B: 255, A: 255}, // light blue
+			color.NRGBA{R: 255, G: 255, B: 255, A: 255}, // white
+			color.NRGBA{R: 170, G: 170, B: 170, A: 255}, // grey
+		},
+		lightAI: color.NRGBA{R: 204, G: 121, B: 167, A: 255},
+		darkAI:  color.NRGBA{R: 213, G: 94, B: 0, A: 255},
+	},
+	PaletteTritan: {
+		light: [9]color.Color{nil,
+			color.NRGBA{R: 0, G: 100, B: 100, A: 255}, // teal
+			color.NRGBA{R: 200, G: 0, B: 0, A: 255},   // red
+			color.NRGBA{R: 0, G: 0, B: 0, A: 255},     // black
+			color.NRGBA{R: 120, G: 0, B: 60, A: 255},  // wine
+			color.NRGBA{R: 0, G: 60, B: 60, A: 255},   // dark teal
+			color.NRGBA{R: 150, G: 60, B: 60, A: 255}, // brick
+			color.NRGBA{R: 60, G: 60, B: 60, A: 255},  // dark grey
+			color.NRGBA{R: 100, G: 100, B: 100, A: 255},
+		},
+		dark: [9]color.Color{nil,
+			color.NRGBA{R: 0, G: 220, B: 220, A: 255},   // cyan
+			color.NRGBA{R: 255, G: 90, B: 90, A: 255},   // light red
+			color.NRGBA{R: 255, G: 255, B: 255, A: 255}, // white
+			color.NRGBA{R: 255, G: 140, B: 200, A: 255}, // pink
+			color.NRGBA{R: 120, G: 255, B: 240, A: 255}, // pale cyan
+			color.NRGBA{R: 255, G: 170, B: 170, A: 255}, // pale red
+			color.NRGBA{R: 200, G: 200, B: 200, A: 255}, // light grey
+			color.NRGBA{R: 140, G: 140, B: 140, A: 255},
+		},
+		lightAI: color.NRGBA{R: 200, G: 0, B: 120, A: 255},
+		darkAI:  color.NRGBA{R: 255, G: 110, B: 200, A: 255},
+	},
+}
+
+// Themes already recoloured by withPalette, so the icons are only made once
+var paletteThemes = map[string]*BoardTheme{}
+
+// Names of the palettes in the order the Settings screen lists them
+// Inputs: None
+// Outputs: Palette names
+func PaletteNames() []string {
+	return []string{PaletteTheme, PaletteOkabeIto, PaletteTritan}
+}
+
+// Gives a copy of a theme with its number and AI colours swapped for a colour-blind palette
+// Inputs: The theme and the palette name (PaletteTheme or an unknown name gives the theme back unchanged)
+// Outputs: The recoloured theme
+func withPalette(th *BoardTheme, name string) *BoardTheme {
+	p, ok := palettes[name]
+	if !ok {
+		return th
+	}
+	key := th.Name + "/" + name
+	if cached, ok := paletteThemes[key]; ok {
+		return cached
+	}
+
+	recoloured := *th
+	if th.Variant == theme.VariantDark {
+		recoloured.Numbers, recoloured.AI = p.dark, p.darkAI
+	} else {
+		recoloured.Numbers, recoloured.AI = p.light, p.lightAI
+	}
+	recoloured.mark = aiMarkIcon(key, recoloured.AI)
+	paletteThemes[key] = &recoloured
+	return &recoloured
+}
+
+// Makes the corner marker drawn on cells the AI revealed: a solid corner triangle with a striped edge, so it is
+// recognised by its shape and not only its colour
+// Inputs: Name for the resource and the marker colour
+// Outputs: SVG icon
+func aiMarkIcon(name string, c color.Color) fyne.Resource {
+	return fyne.NewStaticResource(name+"-ai.svg", []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<polygon points="10,0 16,0 16,6" fill="%[1]s"/>
+<line x1="7" y1="0" x2="16" y2="9" stroke="%[1]s" stroke-width="1"/>
+<line x1="4" y1="0" x2="16" y2="12" stroke="%[1]s" stroke-width="0.75" stroke-dasharray="1 1"/>
+</svg>`, hexColour(c))))
+}
+
+// Turns a colour into "#rrggbb" for the SVG icons
+func hexColour(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+}
+
+// Describes a cell in plain words for screen readers and speech
+// Inputs: Game handler and the cell
+// Outputs: Description such as "c4, covered" or "c4, 3 mines nearby, revealed by the AI"
+func describeCell(h *Gamehandler, row int, col int) string {
+	sq := h.board[row][col]
+	text := cellName(row, col) + ", "
+	switch {
+	case sq.state == Flagged && sq.flags > 1:
+		text += fmt.Sprintf("flagged %d times", sq.flags)
+	case sq.state == Flagged:
+		text += "flagged"
+	case sq.state == Questioned:
+		text += "question mark"
+	case sq.state == Covered:
+		text += "covered"
+	case sq.exploded && sq.bombs > 1:
+		text += fmt.Sprintf("exploded, %d mines", sq.bombs)
+	case sq.exploded:
+		text += "exploded mine"
+	case sq.bombs > 1:
+		text += fmt.Sprintf("%d mines", sq.bombs)
+	case sq.isBomb():
+		text += "mine"
+	case sq.numValue == 0:
+		text += "empty"
+	case sq.numValue == 1:
+		text += "1 mine nearby"
+	default:
+		text += fmt.Sprintf("%d mines nearby", sq.numValue)
+	}
+	if sq.markedByAI && sq.state == Uncovered {
+		text += ", revealed by the AI"
+	}
+	if sq.player > 0 && h.hotseat != nil {
+		text += ", uncovered by " + h.hotseat.names[sq.player-1]
+	}
+	return text
+}
+
+// Describes how the game ended, with the score in the score modes (scoremode.go)
+// Inputs: Game handler
+// Outputs: Description of the result, or "" while the game is still going
+func describeResult(h *Gamehandler) string {
+	if !h.gameOver {
+		return ""
+	}
+	if h.scored() && h.score() == 1 {
+		return describeEnd(h) + " Score: 1 cell cleared."
+	}
+	if h.scored() {
+		return fmt.Sprintf("%s Score: %d cells cleared.", describeEnd(h), h.score())
+	}
+	return describeEnd(h)
+}
+
+// Describes why the game ended
+// Inputs: Game handler of a finished game
+// Outputs: Description of the end
+func describeEnd(h *Gamehandler) string {
+	if h.limitReached && h.timeLimit > 0 {
+		return "Time's up!"
+	}
+	if h.limitReached {
+		return "Out of moves!"
+	}
+	if hs := h.hotseat; hs != nil {
+		result := turnText(h)
+		if h.hotseatWinner() < 0 {
+			result = "it's a draw!"
+		}
+		scores := fmt.Sprintf("%s cleared %d cells and %s %d.", hs.names[0], h.cleared(0), hs.names[1], h.cleared(1))
+		if hs.loser >= 0 {
+			return hs.names[hs.loser] + " hit a mine, " + result + " " + scores
+		}
+		return "Every safe cell is uncovered, " + result + " " + scores
+	}
+	if h.win && h.puzzle != nil {
+		return "Puzzle " + puzzleStatus(PuzzleResult{Solved: true, Mistakes: h.puzzle.mistakes}) + "."
+	}
+	if h.win && h.startLives > 1 {
+		return fmt.Sprintf("You win! Every safe cell is uncovered with %d of %d lives left.", h.lives, h.startLives)
+	}
+	if h.win {
+		return "You win! Every safe cell is uncovered."
+	}
+	if h.startLives > 1 {
+		return "Game over, every life is gone."
+	}
+	return "Game over, a mine was hit."
+}
+
+// Reads a message out loud with the system's text-to-speech command (spd-say on Linux, say on macOS, the speech
+// synthesizer through PowerShell on Windows) when speech is turned on in the settings. It does not wait for the
+// speech to finish and quietly does nothing if the command is missing. The text can hold names the players typed, so
+// it never goes where it could be read as an option or as PowerShell code
+// Inputs: Message to read
+// Outputs: None
+func speak(text string) {
+	if !config.Current.Speak || text == "" {
+		return
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("say", "--", text)
+	case "windows":
+		// The script reads the text from an environment variable, it is never part of the command line
+		cmd = exec.Command("powershell", "-NoProfile", "-Command",
+			"Add-Type -AssemblyName System.Speech; (New-Object System.Speech.Synthesis.SpeechSynthesizer).Speak($env:MS_SPEAK_TEXT)")
+		cmd.Env = append(os.Environ(), "MS_SPEAK_TEXT="+text)
+	default:
+		cmd = exec.Command("spd-say", "--cancel", "--", text) // --cancel stops the last message so fast key presses don't queue up
+	}
+	if err := cmd.Start(); err == nil {
+		go cmd.Wait()
+	}
+}
@@ -0,0 +1,670 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the Fyne widget for the game board. Each BoardWidget owns its game handler and its own renderer, so
+nothing about a board lives in package globals and several boards can be on screen at once. The renderer remembers
+what every cell looked like the last time it was drawn and only redraws the cells that changed on Refresh.
+The cell size is worked out from the space the board is given (times a zoom factor) so the board grows and shrinks with
+the window, scrolls once it is bigger than the window, and the row/column headers always line up with the cells.
+Colours and the tile/mine/flag icons come from the board theme (theme.go). Cells the AI revealed can get a corner
+marker and every move is described in words through OnAnnounce (accessibility.go). Boards on the hex grid (topology.go)
+are drawn as hexagons with their point at the top: the rows overlap by a quarter of a cell, every odd row is pushed half
+a cell to the right and a click goes to the cell whose center is closest. Wrap-around boards get a bar along each edge
+in the cursor colour to show the edges join up. When cells can hold several mines (multimine.go) a flagged cell with
+more than one flag, and a revealed cell with more than one mine, shows the count in its bottom right corner. A mine
+that went off in a lives game (lives.go) is drawn on a burst in the flag colour, and in a hot-seat game (hotseat.go)
+each uncovered cell's floor is tinted with the colour of the player who uncovered it
+
+Functions:
+- NewBoardWidget: Creates a board widget for a game
+
+- CreateRenderer: Creates the header/cell/flag/cursor objects for the widget
+
+- Tapped/TappedSecondary: Turns a left/right click into a reveal/flag on the clicked cell
+
+- cellAt: Finds which cell a click position is on (the closest cell center on the hex grid)
+
+- zoomBy/scrollTo: Zoom in/out and scroll a cell into view (used by the zoom buttons and the keyboard)
+
+- Layout/MinSize: Work out the cell size and place every object
+
+- boardSize/cellPos: The size of the whole board and the position of a cell, for either grid
+
+- placeCount: Puts a cell's mine/flag count in its corner
+
+- Refresh: Redraws the cells that changed since the last Refresh, plus the keyboard cursor
+
+- cellLookFor: Works out what a square should look like (number and its colour, mine, covered or not, flagged or not,
+and the mine/flag count)
+
+Inputs:
+- Game handler board, clicks/keys on the board and the size it is given
+
+Outputs:
+- The drawn board
+*/
+
+package components
+
+import (
+	"image/color"
+	"math"
+	"minesweeper/config"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Zoom limits and how much one zoom step changes it
+const (
+	minZoom  = 0.5
+	maxZoom  = 4
+	zoomStep = 1.25
+)
+
+// Shape of the hex grid for a cell width of 1: a hexagon is 2/sqrt(3) high and the rows are sqrt(3)/2 apart
+const (
+	hexHeight  = 1.1547005
+	hexRowStep = 0.8660254
+)
+
+// Width of the bars along the edges of a wrap-around board for a cell width of 1
+const wrapEdge = 0.1
+
+// BoardWidget draws a game board and turns clicks/keys on it into moves
+type BoardWidget struct {
+	widget.BaseWidget
+
+	handler   *Gamehandler
+	theme     *BoardTheme
+	aiMarkers bool // Draw the corner marker on cells the AI revealed
+	ReadOnly  bool // When set clicks and keys are ignored, for boards that are only watched
+
+	OnAnnounce func(text string) // Called with a description of the cell after a move or cursor move, for screen readers
+
+	zoom   float32           // 1 fits the board to the visible area
+	scroll *container.Scroll // Scroll container the board sits in (nil if it is not scrolled), its size is the visible area
+	drawer *boardRenderer    // Renderer made by CreateRenderer, nil until the board is first drawn
+
+	// Keyboard state, see keyboard.go
+	actions     map[fyne.KeyName]string
+	cursorRow   int
+	cursorCol   int
+	cursorShown bool   // Only shown once the keyboard is used
+	status      string // Small text in the top left corner for the typed coordinate and hint results
+	typing      bool   // Whether the go-to key was pressed and a coordinate is being typed
+	typed       string // What has been typed so far
+}
+
+var _ fyne.Tappable = (*BoardWidget)(nil)
+var _ fyne.SecondaryTappable = (*BoardWidget)(nil)
+var _ fyne.Focusable = (*BoardWidget)(nil)
+
+// Creates a board widget for a game, drawn with the theme from the settings
+// Inputs: game handler to draw and play on
+// Outputs: The widget, put it in a scroll container with inScroll if it should zoom/scroll
+func NewBoardWidget(handler *Gamehandler) *BoardWidget {
+	b := &BoardWidget{handler: handler, theme: currentTheme(), aiMarkers: config.Current.AIMarkers, zoom: 1, actions: map[fyne.KeyName]string{}}
+	keys := currentKeymap()
+	for _, a := range keyActions { // In the Settings order, so a key bound twice in an old settings file always does the same thing
+		for _, name := range keys[a.action] {
+			if _, taken := b.actions[name]; !taken {
+				b.actions[name] = a.action
+			}
+		}
+	}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// Wraps the board in a scroll container, the board then fits itself to the visible area of it
+// Inputs: None
+// Outputs: Scroll container holding the board
+func (b *BoardWidget) inScroll() *container.Scroll {
+	b.scroll = container.NewScroll(b)
+	return b.scroll
+}
+
+// Lets the board draw a different game (e.g. stepping through a replay)
+// Inputs: game handler to draw
+// Outputs: None, redraws the board
+func (b *BoardWidget) SetHandler(handler *Gamehandler) {
+	b.handler = handler
+	b.Refresh()
+}
+
+// CreateRenderer builds the objects that draw the board
+func (b *BoardWidget) CreateRenderer() fyne.WidgetRenderer {
+	r := &boardRenderer{board: b}
+	r.build()
+	b.drawer = r
+	return r
+}
+
+// Tapped reveals the clicked cell
+func (b *BoardWidget) Tapped(ev *fyne.PointEvent) {
+	b.requestFocus()
+	if row, col, ok := b.cellAt(ev.Position); ok && !b.ReadOnly {
+		revealCell(b.handler, row, col)
+		b.announce(describeCell(b.handler, row, col))
+	}
+}
+
+// TappedSecondary flags the right clicked cell
+func (b *BoardWidget) TappedSecondary(ev *fyne.PointEvent) {
+	b.requestFocus()
+	if row, col, ok := b.cellAt(ev.Position); ok && !b.ReadOnly {
+		flagCell(b.handler, row, col)
+		b.announce(describeCell(b.handler, row, col))
+	}
+}
+
+// Passes a description on to OnAnnounce if it is set
+func (b *BoardWidget) announce(text string) {
+	if b.OnAnnounce != nil {
+		b.OnAnnounce(text)
+	}
+}
+
+// Focuses the board so key presses come to it
+func (b *BoardWidget) requestFocus() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(b); c != nil {
+		c.Focus(b)
+	}
+}
+
+// Finds the cell under a position on the widget
+// Inputs: Position relative to the widget
+// Outputs: row/col of the cell and false if the position is on the headers or off the board
+func (b *BoardWidget) cellAt(pos fyne.Position) (int, int, bool) {
+	r := b.drawer
+	if r == nil || r.cell == 0 {
+		return 0, 0, false
+	}
+	if r.hex {
+		return r.hexCellAt(pos)
+	}
+	col := int((pos.X-r.origin.X)/r.cell) - 1
+	row := int((pos.Y-r.origin.Y)/r.cell) - 1
+	if pos.X < r.origin.X || pos.Y < r.origin.Y || !isiInbounds(b.handler, row, col) {
+		return 0, 0, false
+	}
+	return row, col, true
+}
+
+// Finds the hexagon under a position: the cell whose center is closest, as long as the position is no further from it
+// than a corner is
+// Inputs: Position relative to the widget
+// Outputs: row/col of the cell and false if the position is on the headers or off the board
+func (r *boardRenderer) hexCellAt(pos fyne.Position) (int, int, bool) {
+	best := r.cell * hexHeight / 2
+	found, bestRow, bestCol := false, 0, 0
+	near := int((pos.Y - r.origin.Y - r.cell) / (r.cell * hexRowStep))
+	for row := near - 1; row <= near+1; row++ {
+		shift := float32(row%2) / 2
+		nearCol := int((pos.X-r.origin.X)/r.cell - 1 - shift)
+		for col := nearCol - 1; col <= nearCol+1; col++ {
+			if !isiInbounds(r.board.handler, row, col) {
+				continue
+			}
+			center := r.cellPos(row+1, col+1).AddXY(r.cell/2, r.cell/2)
+			dx, dy := float64(pos.X-center.X), float64(pos.Y-center.Y)
+			if d := float32(math.Hypot(dx, dy)); d <= best {
+				found, best, bestRow, bestCol = true, d, row, col
+			}
+		}
+	}
+	return bestRow, bestCol, found
+}
+
+// Changes the zoom by a factor (zoomStep to zoom in, 1/zoomStep to zoom out) within the zoom limits
+// Inputs: Factor to multiply the zoom by
+// Outputs: None, relays out the board
+func (b *BoardWidget) zoomBy(factor float32) {
+	b.zoom = min(max(b.zoom*factor, minZoom), maxZoom)
+	if b.scroll != nil {
+		b.scroll.Refresh()
+	}
+	b.Refresh()
+}
+
+// Scrolls just enough that the given cell is visible (keeping the header next to it in view)
+func (b *BoardWidget) scrollTo(row int, col int) {
+	r := b.drawer
+	if b.scroll == nil || r == nil {
+		return
+	}
+	view := b.scroll.Size()
+	pos := r.cellPos(row+1, col+1)
+	offset := b.scroll.Offset
+	if pos.X < offset.X+r.cell {
+		offset.X = max(0, pos.X-r.cell)
+	} else if pos.X+r.cell > offset.X+view.Width {
+		offset.X = pos.X + r.cell - view.Width
+	}
+	if pos.Y < offset.Y+r.cell {
+		offset.Y = max(0, pos.Y-r.cell)
+	} else if pos.Y+r.cell > offset.Y+view.Height {
+		offset.Y = pos.Y + r.cell - view.Height
+	}
+	if offset != b.scroll.Offset {
+		b.scroll.Offset = offset
+		b.scroll.Refresh()
+	}
+}
+
+// What a single cell looks like, the renderer compares these to know which cells changed
+type cellLook struct {
+	text       string
+	color      color.Color
+	mine       bool
+	exploded   bool // A mine that went off in a lives game
+	ai         bool // Revealed by the AI
+	player     int  // Hot-seat player who uncovered it, their colour tints the floor (0 for none)
+	covered    bool
+	flagged    bool
+	questioned bool
+	count      string // Mines on a revealed mine or flags on a flagged cell when there is more than one
+}
+
+// Works out what a square should look like: the number in the theme's colour for it (or the AI colour if the AI
+// revealed it), or the mine icon for bombs, with the count in the corner for cells holding several mines or flags
+// Inputs: Square to draw and the board theme
+// Outputs: cellLook for the square
+func cellLookFor(sq Square, th *BoardTheme) cellLook {
+	look := cellLook{
+		color:      th.Header,
+		mine:       sq.isBomb(),
+		exploded:   sq.exploded,
+		ai:         sq.markedByAI,
+		player:     sq.player,
+		covered:    sq.state != Uncovered,
+		flagged:    sq.state == Flagged,
+		questioned: sq.state == Questioned,
+	}
+	if sq.state == Flagged && sq.flags > 1 {
+		look.count = strconv.Itoa(sq.flags)
+	} else if sq.state == Uncovered && sq.bombs > 1 {
+		look.count = strconv.Itoa(sq.bombs)
+	}
+	if !sq.isBomb() && sq.numValue != 0 {
+		look.text = strconv.Itoa(sq.numValue)
+		look.color = th.Numbers[min(sq.numValue, 8)]
+		if sq.markedByAI {
+			look.color = th.AI
+		}
+	}
+	return look
+}
+
+// boardRenderer holds the canvas objects for one BoardWidget
+type boardRenderer struct {
+	board *BoardWidget
+	rows  int
+	cols  int
+	grid  string // Grid of the board when the objects were made (config.Grid...)
+	hex   bool   // Drawn as hexagons (the board is on the hex grid)
+
+	cell   float32       // Cell size from the last Layout call
+	origin fyne.Position // Top left of the header corner, the board is centered when it is smaller than its space
+
+	colHeaders []*canvas.Text
+	rowHeaders []*canvas.Text
+	floors     [][]fyne.CanvasObject // Uncovered cell background with the grid line around it
+	texts      [][]*canvas.Text      // Underlying number
+	mines      [][]*canvas.Image
+	blasts     [][]*canvas.Image // Burst behind mines that went off (lives game)
+	marks      [][]*canvas.Image // AI corner markers
+	covers     [][]*canvas.Image // Tile over a cell until it is uncovered
+	flags      [][]*canvas.Image
+	questions  [][]*canvas.Text    // "?" on question marked cells
+	counts     [][]*canvas.Text    // Mine or flag count in the bottom right corner (multi-mine game)
+	drawn      [][]cellLook        // What each cell looked like when it was last drawn
+	edges      []*canvas.Rectangle // Bars along the left, right, top and bottom edges of a wrap-around board
+	cursor     *canvas.Rectangle
+	status     *canvas.Text
+	objects    []fyne.CanvasObject
+}
+
+// Creates every object for the current board size, in drawing order (floors, texts, mines and AI markers, then covers,
+// then flags, question marks and counts, then the wrap-around edges and the cursor)
+func (r *boardRenderer) build() {
+	board := r.board.handler.board
+	th := r.board.theme
+	r.rows = len(board)
+	r.cols = len(board[0])
+	r.grid = r.board.handler.gridName()
+	r.hex = r.grid == config.GridHex
+	tile := th.tile
+	if r.hex {
+		tile = th.hexTile
+	}
+	r.objects = make([]fyne.CanvasObject, 0, (r.rows+1)*(r.cols+1)*7+2)
+
+	r.colHeaders = make([]*canvas.Text, r.cols)
+	for c := range r.colHeaders {
+		r.colHeaders[c] = canvas.NewText(columnLabel(c), th.Header)
+		r.objects = append(r.objects, r.colHeaders[c])
+	}
+	r.rowHeaders = make([]*canvas.Text, r.rows)
+	for row := range r.rowHeaders {
+		r.rowHeaders[row] = canvas.NewText(strconv.Itoa(row+1), th.Header)
+		r.objects = append(r.objects, r.rowHeaders[row])
+	}
+
+	r.floors = make([][]fyne.CanvasObject, r.rows)
+	r.texts = make([][]*canvas.Text, r.rows)
+	r.mines = make([][]*canvas.Image, r.rows)
+	r.blasts = make([][]*canvas.Image, r.rows)
+	r.marks = make([][]*canvas.Image, r.rows)
+	r.covers = make([][]*canvas.Image, r.rows)
+	r.flags = make([][]*canvas.Image, r.rows)
+	r.questions = make([][]*canvas.Text, r.rows)
+	r.counts = make([][]*canvas.Text, r.rows)
+	r.drawn = make([][]cellLook, r.rows)
+	for row := 0; row < r.rows; row++ {
+		r.floors[row] = make([]fyne.CanvasObject, r.cols)
+		r.texts[row] = make([]*canvas.Text, r.cols)
+		r.mines[row] = make([]*canvas.Image, r.cols)
+		r.blasts[row] = make([]*canvas.Image, r.cols)
+		r.marks[row] = make([]*canvas.Image, r.cols)
+		r.covers[row] = make([]*canvas.Image, r.cols)
+		r.flags[row] = make([]*canvas.Image, r.cols)
+		r.questions[row] = make([]*canvas.Text, r.cols)
+		r.counts[row] = make([]*canvas.Text, r.cols)
+		r.drawn[row] = make([]cellLook, r.cols)
+		for col := 0; col < r.cols; col++ {
+			var floor fyne.CanvasObject
+			if r.hex {
+				floor = canvas.NewImageFromResource(th.hexFloor)
+			} else {
+				rect := canvas.NewRectangle(th.Floor)
+				rect.StrokeColor = th.Grid
+				rect.StrokeWidth = 1
+				floor = rect
+			}
+			r.floors[row][col] = floor
+
+			text := canvas.NewText("", th.Header)
+			text.TextStyle.Bold = true
+			r.texts[row][col] = text
+
+			blast := canvas.NewImageFromResource(th.blast)
+			blast.Hide()
+			r.blasts[row][col] = blast
+
+			mine := canvas.NewImageFromResource(th.mine)
+			mine.Hide()
+			r.mines[row][col] = mine
+
+			mark := canvas.NewImageFromResource(th.mark)
+			mark.Hide()
+			r.marks[row][col] = mark
+
+			r.objects = append(r.objects, floor, text, blast, mine, mark)
+		}
+	}
+	for row := 0; row < r.rows; row++ {
+		for col := 0; col < r.cols; col++ {
+			// New objects start out covered with an empty text, drawCell then only changes what differs from that
+			r.drawn[row][col] = cellLook{color: th.Header, covered: true}
+			r.covers[row][col] = canvas.NewImageFromResource(tile)
+
+			flag := canvas.NewImageFromResource(th.flag)
+			flag.Hide()
+			r.flags[row][col] = flag
+
+			question := canvas.NewText("?", th.Question)
+			question.TextStyle.Bold = true
+			question.Hide()
+			r.questions[row][col] = question
+
+			count := canvas.NewText("", th.Question)
+			count.TextStyle.Bold = true
+			r.counts[row][col] = count
+
+			r.objects = append(r.objects, r.covers[row][col], flag, question, count)
+			r.drawCell(row, col, cellLookFor(board[row][col], th))
+		}
+	}
+
+	r.edges = nil
+	if r.grid == config.GridTorus {
+		for range 4 {
+			edge := canvas.NewRectangle(th.Cursor)
+			r.edges = append(r.edges, edge)
+			r.objects = append(r.objects, edge)
+		}
+	}
+
+	r.cursor = canvas.NewRectangle(color.Transparent)
+	r.cursor.StrokeColor = th.Cursor
+	r.cursor.StrokeWidth = 3
+	r.cursor.Hide()
+	r.status = canvas.NewText("", th.Cursor)
+	r.objects = append(r.objects, r.cursor, r.status)
+}
+
+// Works out the cell size: as big as fits in the visible area, times the zoom, but never smaller than config.MinCellSize
+func (r *boardRenderer) cellSize(size fyne.Size) float32 {
+	if r.board.scroll != nil && !r.board.scroll.Size().IsZero() {
+		size = r.board.scroll.Size()
+	}
+	fit := float32(config.MinCellSize)
+	if !size.IsZero() {
+		unit := r.boardSize(1)
+		fit = min(size.Width/unit.Width, size.Height/unit.Height)
+	}
+	return max(fit*r.board.zoom, config.MinCellSize)
+}
+
+// Size of the whole board (headers + cells) for a cell size, hex rows overlap and odd ones stick out half a cell and
+// wrap-around boards have room for the bars on the right and bottom edges
+func (r *boardRenderer) boardSize(cell float32) fyne.Size {
+	if r.grid == config.GridTorus {
+		return fyne.NewSize(cell*(float32(r.cols+1)+wrapEdge), cell*(float32(r.rows+1)+wrapEdge))
+	}
+	if !r.hex {
+		return fyne.NewSize(cell*float32(r.cols+1), cell*float32(r.rows+1))
+	}
+	width := cell * float32(r.cols+1)
+	if r.rows > 1 {
+		width += cell / 2
+	}
+	return fyne.NewSize(width, cell*(1+hexHeight)+cell*hexRowStep*float32(r.rows-1))
+}
+
+// MinSize is the whole board (headers + cells) at the current cell size
+func (r *boardRenderer) MinSize() fyne.Size {
+	return r.boardSize(r.cellSize(r.board.Size()))
+}
+
+// Layout places every object for the cell size that fits the given size
+func (r *boardRenderer) Layout(size fyne.Size) {
+	r.cell = r.cellSize(size)
+	board := r.boardSize(r.cell)
+	r.origin = fyne.NewPos(max(0, (size.Width-board.Width)/2), max(0, (size.Height-board.Height)/2))
+
+	for c, t := range r.colHeaders {
+		r.centerText(t, 0, c+1, 0.5)
+	}
+	for row, t := range r.rowHeaders {
+		r.centerText(t, row+1, 0, 0.5)
+	}
+	for row := 0; row < r.rows; row++ {
+		for col := 0; col < r.cols; col++ {
+			pos := r.cellPos(row+1, col+1)
+			for _, obj := range []fyne.CanvasObject{r.floors[row][col], r.blasts[row][col], r.marks[row][col], r.covers[row][col], r.flags[row][col]} {
+				obj.Resize(fyne.NewSize(r.cell, r.cell))
+				obj.Move(pos)
+			}
+			if r.hex {
+				// The hexagons are taller than the square the icons and text sit in
+				for _, obj := range []fyne.CanvasObject{r.floors[row][col], r.covers[row][col]} {
+					obj.Resize(fyne.NewSize(r.cell, r.cell*hexHeight))
+					obj.Move(pos.SubtractXY(0, r.cell*(hexHeight-1)/2))
+				}
+			}
+			r.centerText(r.texts[row][col], row+1, col+1, 0.5)
+			r.centerText(r.questions[row][col], row+1, col+1, 0.5)
+			r.placeCount(row, col)
+			r.mines[row][col].Resize(fyne.NewSize(r.cell*0.8, r.cell*0.8))
+			r.mines[row][col].Move(pos.AddXY(r.cell*0.1, r.cell*0.1))
+		}
+	}
+	if len(r.edges) == 4 {
+		// Just outside the cells, between them and the headers
+		first, last := r.cellPos(1, 1), r.cellPos(r.rows, r.cols).AddXY(r.cell, r.cell)
+		w := r.cell * wrapEdge
+		r.edges[0].Move(fyne.NewPos(first.X-w, first.Y))
+		r.edges[1].Move(fyne.NewPos(last.X, first.Y))
+		for _, edge := range r.edges[:2] {
+			edge.Resize(fyne.NewSize(w, last.Y-first.Y))
+		}
+		r.edges[2].Move(fyne.NewPos(first.X, first.Y-w))
+		r.edges[3].Move(fyne.NewPos(first.X, last.Y))
+		for _, edge := range r.edges[2:] {
+			edge.Resize(fyne.NewSize(last.X-first.X, w))
+		}
+	}
+	r.cursor.Resize(fyne.NewSize(r.cell, r.cell))
+	r.cursor.Move(r.cellPos(r.board.cursorRow+1, r.board.cursorCol+1))
+	r.centerText(r.status, 0, 0, 1.0/3)
+}
+
+// Top left corner of a cell, row/col 0 are the headers. On the hex grid this is the corner of the square in the middle
+// of the hexagon, odd board rows (even rows here as row 0 is the header) are pushed half a cell to the right
+func (r *boardRenderer) cellPos(row int, col int) fyne.Position {
+	if !r.hex || row == 0 {
+		return fyne.NewPos(r.origin.X+float32(col)*r.cell, r.origin.Y+float32(row)*r.cell)
+	}
+	x := r.origin.X + float32(col)*r.cell
+	if col > 0 && row%2 == 0 {
+		x += r.cell / 2
+	}
+	y := r.origin.Y + r.cell + float32(row-1)*r.cell*hexRowStep + r.cell*(hexHeight-1)/2
+	return fyne.NewPos(x, y)
+}
+
+// Sizes a text relative to the cell and centers it in that cell (row/col 0 are the headers)
+// Inputs: The text, the row/col to center it in and the text size as a fraction of the cell
+// Outputs: None, moves the text
+func (r *boardRenderer) centerText(t *canvas.Text, row int, col int, scale float32) {
+	t.TextSize = r.cell * scale
+	sz := t.MinSize()
+	pos := r.cellPos(row, col)
+	t.Move(fyne.NewPos(pos.X+(r.cell-sz.Width)/2, pos.Y+(r.cell-sz.Height)/2))
+}
+
+// Sizes a cell's count text and puts it in the bottom right corner of the cell
+func (r *boardRenderer) placeCount(row int, col int) {
+	t := r.counts[row][col]
+	t.TextSize = r.cell * 0.35
+	sz := t.MinSize()
+	t.Move(r.cellPos(row+1, col+1).AddXY(r.cell*0.95-sz.Width, r.cell-sz.Height))
+}
+
+// Refresh redraws only the cells whose look changed, plus the keyboard cursor/status
+func (r *boardRenderer) Refresh() {
+	board := r.board.handler.board
+	if len(board) != r.rows || len(board[0]) != r.cols || r.grid != r.board.handler.gridName() {
+		// A different sized board (or one on the other grid) was swapped in, start over
+		r.build()
+		r.Layout(r.board.Size())
+		canvas.Refresh(r.board)
+		return
+	}
+
+	for row := 0; row < r.rows; row++ {
+		for col := 0; col < r.cols; col++ {
+			look := cellLookFor(board[row][col], r.board.theme)
+			if look != r.drawn[row][col] {
+				r.drawCell(row, col, look)
+			}
+		}
+	}
+
+	r.cursor.Move(r.cellPos(r.board.cursorRow+1, r.board.cursorCol+1))
+	r.cursor.Hidden = !r.board.cursorShown
+	r.cursor.Refresh()
+	if r.status.Text != r.board.status {
+		r.status.Text = r.board.status
+		r.centerText(r.status, 0, 0, 1.0/3)
+		r.status.Refresh()
+	}
+}
+
+// Updates one cell's objects to a new look and refreshes just those objects
+func (r *boardRenderer) drawCell(row int, col int, look cellLook) {
+	if look.player != r.drawn[row][col].player {
+		r.paintFloor(row, col, look.player)
+	}
+	r.drawn[row][col] = look
+
+	t := r.texts[row][col]
+	if t.Text != look.text || t.Color != look.color {
+		t.Text = look.text
+		t.Color = look.color
+		if r.cell > 0 {
+			r.centerText(t, row+1, col+1, 0.5) // the text width changed so center it again
+		}
+		t.Refresh()
+	}
+	showIf(r.blasts[row][col], look.exploded)
+	showIf(r.mines[row][col], look.mine)
+	showIf(r.marks[row][col], look.ai && r.board.aiMarkers)
+	showIf(r.covers[row][col], look.covered)
+	showIf(r.flags[row][col], look.flagged)
+	showIf(r.questions[row][col], look.questioned)
+	if c := r.counts[row][col]; c.Text != look.count {
+		c.Text = look.count
+		if r.cell > 0 {
+			r.placeCount(row, col)
+		}
+		c.Refresh()
+	}
+}
+
+// Colours a cell's floor with the colour of the hot-seat player who uncovered it, or the theme's floor colour
+func (r *boardRenderer) paintFloor(row int, col int, player int) {
+	th := r.board.theme
+	switch floor := r.floors[row][col].(type) {
+	case *canvas.Rectangle:
+		floor.FillColor = th.Floor
+		if player > 0 {
+			floor.FillColor = th.Players[player-1]
+		}
+	case *canvas.Image:
+		floor.Resource = th.hexFloor
+		if player > 0 {
+			floor.Resource = th.hexPlayers[player-1]
+		}
+	}
+	r.floors[row][col].Refresh()
+}
+
+// Objects gives every object of the board in drawing order
+func (r *boardRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// Destroy has nothing to clean up
+func (r *boardRenderer) Destroy() {}
+
+// Shows or hides an object, only refreshing it if that changed
+func showIf(obj fyne.CanvasObject, show bool) {
+	if obj.Visible() != show {
+		if show {
+			obj.Show()
+		} else {
+			obj.Hide()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file converts between the zero-based row/col used by the game handler and the coordinate names shown in the board
+headers (columns are letters a, b, c..., rows are numbers starting at 1), so "c4" means column c and row 4
+
+Functions:
+- columnLabel: Turns a column index into its header letters
+
+- cellName: Turns a row/col into a coordinate name like "c4"
+
+- parseCoordinate: Turns a coordinate name like "c4" back into a row/col
+
+Inputs:
+- Row/col indexes or typed coordinate text
+
+Outputs:
+- Header letters/coordinate names or the row/col they point to
+*/
+
+package components
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Gives the header letters for a column, after z it carries on like a spreadsheet (aa, ab, ...)
+// Inputs: zero-based column index
+// Outputs: Header letters for that column
+func columnLabel(col int) string {
+	label := ""
+	for col >= 0 {
+		label = string(rune('a'+col%26)) + label
+		col = col/26 - 1
+	}
+	return label
+}
+
+// Gives the coordinate name of a cell
+// Inputs: zero-based row/col
+// Outputs: Name such as "c4"
+func cellName(row, col int) string {
+	return columnLabel(col) + strconv.Itoa(row+1)
+}
+
+// Reads a coordinate name such as "c4" (letters then the row number), does not check it against the board size
+// Inputs: Typed coordinate text
+// Outputs: zero-based row/col and whether the text was a coordinate at all
+func parseCoordinate(text string) (int, int, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	// Split the column letters from the row digits
+	split := 0
+	for split < len(text) && text[split] >= 'a' && text[split] <= 'z' {
+		split++
+	}
+	if split == 0 || split == len(text) {
+		return 0, 0, false
+	}
+
+	col := 0
+	for _, ch := range text[:split] {
+		col = col*26 + int(ch-'a') + 1
+	}
+	row, err := strconv.Atoi(text[split:])
+	if err != nil || row < 1 {
+		return 0, 0, false
+	}
+	return row - 1, col - 1, true
+}
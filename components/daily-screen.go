@@ -0,0 +1,122 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the daily challenge screen, opened with Daily Challenge on the game mode screen and after a challenge
+ends. It shows today's board, the result if today's board was already finished (the Play button is turned off then)
+and the leaderboard of any day that has results
+
+Functions:
+- showDaily: Shows the daily challenge screen
+
+- leaderboardText: Writes a day's leaderboard as numbered lines
+
+- formatDuration: Writes a time taken as minutes and seconds
+
+Inputs:
+- The stats store and the player name from the settings
+
+Outputs:
+- The daily challenge game, the leaderboard
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Shows the daily challenge screen for today
+// Inputs: the fyne window itself
+// Outputs: None, replaces the window content
+func showDaily(win fyne.Window) {
+	today := dailyDate(time.Now())
+	stats, err := LoadStats()
+	if err != nil {
+		fmt.Println("could not read the stats:", err)
+	}
+	p, _ := config.PresetNamed(dailyDifficulty)
+
+	title := widget.NewLabel(fmt.Sprintf("Daily Challenge for %s: %s, %dx%d with %d mines. Everyone gets the same "+
+		"board today and has one attempt.", today, p.Label, p.Cols, p.Rows, p.Mines))
+	title.Wrapping = fyne.TextWrapWord
+
+	player := config.Current.PlayerName
+	status := widget.NewLabel(fmt.Sprintf("Playing as %s, you haven't finished today's board yet.", player))
+	status.Wrapping = fyne.TextWrapWord
+	playButton := widget.NewButton("Play Today's Board", func() {
+		h := NewDailyGameHandler(today)
+		ShowGame(win, &h)
+	})
+	playButton.Importance = widget.HighImportance
+	if result, played := dailyResultOf(stats, today); played {
+		outcome := "lost"
+		if result.Won {
+			outcome = "won"
+		}
+		status.SetText(fmt.Sprintf("%s %s today's board in %s, come back tomorrow for a new one.", result.Player, outcome,
+			formatDuration(result.TimeMs)))
+		playButton.Disable()
+	}
+
+	// Leaderboard of today, or of an earlier day picked from the list
+	board := widget.NewLabel("")
+	dates := []string{}
+	for date := range stats.Daily {
+		dates = append(dates, date)
+	}
+	if !slices.Contains(dates, today) {
+		dates = append(dates, today)
+	}
+	slices.Sort(dates)
+	slices.Reverse(dates)
+	dateSelect := widget.NewSelect(dates, func(date string) {
+		board.SetText(leaderboardText(stats.Daily[date]))
+	})
+	dateSelect.SetSelected(today)
+
+	backButton := widget.NewButton("Back", func() {
+		gameSelect(win)
+	})
+
+	top := container.NewVBox(title, status, playButton,
+		container.NewBorder(nil, nil, widget.NewLabel("Leaderboard for"), nil, dateSelect))
+	win.SetContent(container.NewPadded(container.NewBorder(top, backButton, nil, nil, container.NewVScroll(board))))
+}
+
+// Writes a day's leaderboard, one numbered line per player
+// Inputs: The day's results
+// Outputs: Leaderboard text, or a note that nobody has finished that day
+func leaderboardText(results []DailyResult) string {
+	if len(results) == 0 {
+		return "Nobody has finished this board yet."
+	}
+	lines := []string{}
+	for i, result := range dailyLeaderboard(results) {
+		outcome := "lost"
+		if result.Won {
+			outcome = "won"
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s, %s in %s", i+1, result.Player, outcome, formatDuration(result.TimeMs)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Writes a time taken as minutes and seconds, e.g. "2:05.3"
+// Inputs: Milliseconds
+// Outputs: Time text
+func formatDuration(ms int64) string {
+	tenths := (ms + 50) / 100
+	return fmt.Sprintf("%d:%02d.%d", tenths/600, tenths/10%60, tenths%10)
+}
@@ -0,0 +1,122 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the daily challenge, one Intermediate board per calendar day. The seed comes from the date alone and
+the first click policy is fixed, so everyone playing on the same day gets the same board whatever their settings are.
+There is one attempt a day: once it is finished the result goes on the leaderboard in the stats store (stats.go)
+and the day's board can't be played again, whatever player name is set. Moves can't be undone and Restart is not offered during the challenge
+
+Functions:
+- dailyDate: The date of the daily challenge being played today
+
+- dailySeed: The seed of a day's board
+
+- NewDailyGameHandler: Creates the daily challenge board for a date
+
+- dailyResultOf: Finds the result of a date's attempt
+
+- recordDaily: Puts a finished daily challenge on the leaderboard
+
+- dailyLeaderboard: Sorts a day's results, wins first by time then losses by time
+
+Inputs:
+- The date and the finished game
+
+Outputs:
+- The day's board, the leaderboard
+*/
+
+package components
+
+import (
+	"cmp"
+	"hash/fnv"
+	"minesweeper/config"
+	"slices"
+	"time"
+)
+
+// Difficulty of the daily challenge board
+const dailyDifficulty = config.DifficultyIntermediate
+
+// Gives the date of the daily challenge for a moment, the UTC calendar day so the board changes at the same moment
+// everywhere
+// Inputs: The time
+// Outputs: Date such as "2026-10-19"
+func dailyDate(now time.Time) string {
+	return now.UTC().Format("2006-01-02")
+}
+
+// Gives the seed of a day's board, a hash of the date so every copy of the game picks the same one
+// Inputs: Date from dailyDate
+// Outputs: Seed
+func dailySeed(date string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte("minesweeper daily " + date))
+	return int64(hash.Sum64())
+}
+
+// Creates the daily challenge board for a date, the first click policy is always "safe" so the board doesn't depend
+// on the player's settings
+// Inputs: Date from dailyDate
+// Outputs: gameHandler object (single player)
+func NewDailyGameHandler(date string) Gamehandler {
+	p, _ := config.PresetNamed(dailyDifficulty)
+	h := NewSizedGameHandler(p.Rows, p.Cols, p.Mines, dailySeed(date))
+	h.firstClickPolicy = config.FirstClickSafe
+	h.daily = date
+	return h
+}
+
+// Finds the result of a day's attempt, any result stored under the date means the day was played (changing the
+// player name doesn't give another attempt)
+// Inputs: The stats and the date
+// Outputs: The result, and false if that day's challenge hasn't been finished
+func dailyResultOf(stats Stats, date string) (DailyResult, bool) {
+	if results := stats.Daily[date]; len(results) > 0 {
+		return results[0], true
+	}
+	return DailyResult{}, false
+}
+
+// Puts a finished daily challenge on the leaderboard under the player name from the settings, only the first finished
+// attempt of a day counts whoever played it
+// Inputs: gameHandler object of the finished challenge
+// Outputs: Error if the stats could not be read or written
+func recordDaily(handler *Gamehandler) error {
+	stats, err := LoadStats()
+	if err != nil {
+		return err
+	}
+	if _, played := dailyResultOf(stats, handler.daily); played {
+		return nil
+	}
+	stats.Daily[handler.daily] = append(stats.Daily[handler.daily], DailyResult{
+		Player:   config.Current.PlayerName,
+		Won:      handler.win,
+		TimeMs:   handler.playTimeMs(),
+		Finished: time.Now(),
+	})
+	return SaveStats(stats)
+}
+
+// Sorts a day's results for the leaderboard: wins before losses, faster before slower
+// Inputs: The day's results
+// Outputs: Sorted copy
+func dailyLeaderboard(results []DailyResult) []DailyResult {
+	sorted := slices.Clone(results)
+	slices.SortStableFunc(sorted, func(a, b DailyResult) int {
+		if a.Won != b.Won {
+			if a.Won {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.TimeMs, b.TimeMs)
+	})
+	return sorted
+}
@@ -4,8 +4,6 @@ package components
 import (
 	"math/rand"
 	"time"
-
-	"minesweeper/config"
 )
 
 // function for easy AI
@@ -14,6 +12,8 @@ func EasyAIMove(handler *Gamehandler) bool {
 	if handler == nil || handler.gameOver {
 		return false
 	}
+	handler.aiMoving = true // Moves made from here on are recorded as the AI's (replay.go)
+	defer func() { handler.aiMoving = false }()
 	//declare variable for random
 	var rng *rand.Rand
 	//created rng if it not created
@@ -27,11 +27,11 @@ func EasyAIMove(handler *Gamehandler) bool {
 	type cell struct{ r, c int }
 
 	// Collect all covered cells (potential moves) into a slice
-	candidates := make([]cell, 0, config.BoardSize*config.BoardSize)
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
+	candidates := make([]cell, 0, handler.rows*handler.cols)
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
 			sq := &handler.board[r][c]
-			if sq.state == Covered {
+			if sq.isCovered() {
 				candidates = append(candidates, cell{r, c})
 			}
 		}
@@ -14,7 +14,19 @@ Functions:
 	Input: number of mines
 	Output: game handler with the board initialized
 
-- AddNumbers: Makes the number of each square equal to the number representing the adjacent bombs
+- NewSeededGameHandler: Same as NewGameHandler but the bomb placement comes from the given seed so a board can be played again
+	Input: number of mines and the seed
+	Output: game handler with the board initialized
+
+- NewSizedGameHandler: Same as NewSeededGameHandler but for a given board size on the square grid with at most one
+mine per cell (the other two use the size, grid and mines per cell from the settings)
+	Input: rows, columns, number of mines and the seed
+	Output: game handler with the board initialized
+
+- placeMines: Puts the bombs on random cells, a cell can get more than one when the game allows it
+
+- AddNumbers: Makes the number of each square equal to the number representing the adjacent bombs (a square with
+several bombs counts each of them)
 
 - isiInbounds: Helper function, checks if a cell is inside the board
 
@@ -22,16 +34,36 @@ Functions:
 
 - RevealZero: Recursively uncovers zero-valued squares and their neighbors
 
-- Click: Handles all clicks (user click, first click, lose/win, recursive uncovering)
+- Click: Handles all clicks (user click, first click, lose/win, recursive uncovering), the move is recorded for the
+replay (replay.go). In a lives game (lives.go) a bomb only ends the game when it takes the last life
+
+- ToggleFlag: Toggles between flag states on a unrevealed square (covered -> flagged -> question mark -> covered when
+question marks are turned on in the settings), when cells can hold several mines a flagged square takes one more flag
+each time until it has as many as a cell can hold
 
-- ToggleFlag: Toggles between flag states on a unrevealed square
+- Flag: Puts a flag on a covered or question marked square, or one more flag on a flagged square (used by the AIs)
 
 - moveBombFrom: Changes the location of a bomb if the first click is a bomb
 
+- clearOpening: Moves every bomb off the first clicked cell and its neighbors (the "opening" first click policy)
+
 - revealAllBombs: Uncovers all bombs if it's in a lose condition
 
 - checkWin: Check whether the game is in a win condition
 
+- flagCount: Counts the flags currently placed on the board (used by the status lines)
+
+- flagsAround: Counts the flags on the neighbors of a cell
+
+- saveUndo/dropUndo/Undo: Remembers the board before a player move, forgets it again, or goes back to it
+
+- Chord: Reveals the covered neighbors of a number once it has as many flags around it as its value
+
+- RunAIMove/aiStep: Make one move for the selected AI difficulty, the front-ends pace the solver between the calls
+
+- rematch: Creates a new game with the same size, mine count, grid, mines per cell, lives and mode (with its time or move limit) (used by the restart buttons/keys), a game made
+from a layout gets the same layout again and a puzzle the same puzzle
+
 Inputs:
 - Board size
 - Number of mines
@@ -49,8 +81,11 @@ package components
 //Import Library
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"minesweeper/config"
+	"os"
+	"slices"
 	"time"
 )
 
@@ -61,70 +96,167 @@ const (
 	Covered SquareState = iota
 	Uncovered
 	Flagged
+	Questioned // Marked with a question mark, still covered (only reachable when question marks are turned on)
 )
 
 // Define the square struct, this is used for the cells in ui-handler.go but allows you to see cell state/if cell=bomb and the number of neighbors that cell has (if not bomb)
 type Square struct {
 	state      SquareState // If something is covered/uncovered/flagged
-	isBomb     bool        // If something is a bomb
-	numValue   int         // Neighbor count
+	bombs      int         // How many bombs are in the square, 0 if it is not a bomb (more than 1 only in the multi-mine game)
+	flags      int         // How many flags are on a flagged square (more than 1 only in the multi-mine game)
+	numValue   int         // Neighbor count, the bombs of all the neighbors added up
 	markedByAI bool        // Whether the square was clicked by the AI
+	exploded   bool        // A bomb that was hit and cost a life, it stays uncovered (lives games only)
+	player     int         // Hot-seat player (1 or 2) whose move uncovered the square (hotseat.go), 0 in other games
+}
+
+// Tells whether a square has at least one bomb in it
+func (sq Square) isBomb() bool {
+	return sq.bombs > 0
+}
+
+// Tells whether a square is still covered, question marks are only a note for the player so they count as covered
+func (sq Square) isCovered() bool {
+	return sq.state == Covered || sq.state == Questioned
 }
 
 // Gamehandler structs holds the board sets the rng value and whether this is firstclick and if the game is over (win or not) and the total number of mines
 type Gamehandler struct {
-	board      [][]Square // Used to store underlyining board
-	rng        *rand.Rand // Used for bomb generation
-	firstClick bool       // Used to ensure if this is first click + bomb we dont insta lose
-	gameOver   bool       // Used to ensure no more game/also to trigger win/lost message
-	win        bool       // Used to tell ui-handler to show win/lost
-	totalMines int        // Used in NewGameHandler
+	board            [][]Square   // Used to store underlyining board
+	rows             int          // Board height
+	cols             int          // Board width
+	rng              *rand.Rand   // Used for bomb generation
+	seed             int64        // Seed rng was created from, the same seed and mine count give the same board
+	firstClick       bool         // Used to ensure if this is first click + bomb we dont insta lose
+	gameOver         bool         // Used to ensure no more game/also to trigger win/lost message
+	win              bool         // Used to tell ui-handler to show win/lost
+	totalMines       int          // Used in NewGameHandler
+	firstClickPolicy string       // What the first click does when it lands on a bomb (config.FirstClick...)
+	questionMarks    bool         // Whether ToggleFlag goes through the question mark state
+	layout           []string     // Mine layout the game was made from (layout.go), nil for random boards
+	start            []string     // Board the game started from in save file letters, for games that don't start covered (loaded games and puzzles)
+	puzzle           *puzzleState // Puzzle being played (puzzle.go), nil for normal games
+	daily            string       // Date of the daily challenge being played (daily.go), empty for normal games
+	topology         Topology     // Which cells are neighbors (topology.go)
+	cellMines        int          // Most bombs one square can hold, 1 for the classic game (multimine.go)
+	lives            int          // Lives left, hitting a bomb takes one and the game ends at 0 (lives.go)
+	startLives       int          // Lives the game started with, 1 for the classic game
+	recorded         bool         // Whether the result is already in the stats (stats.go), only the first end of a game counts
+	timeLimit        int          // Seconds of a Time Attack game's countdown (scoremode.go), 0 for other games
+	deadline         time.Time    // When the countdown runs out, set by the first reveal
+	moveLimit        int          // Reveals and chords a Move Budget game allows, 0 for other games
+	movesUsed        int          // Reveals and chords made so far in a Move Budget game
+	limitReached     bool         // The game ended because the time or the moves ran out
+	infinite         *chunkBoard  // Board of an infinite game (infinite.go), the handler's board is the part in view
+	hotseat          *hotseatGame // Players of a hot-seat game (hotseat.go), nil for other games
 
 	//Zhang: turn-based AI support
 	aiEnabled    bool   // Whether AI is enabled
 	aiTurn       bool   // Whether it's AI's turn
 	aiDifficulty string // use for diffculty selection
 	aiSolver     bool   // Whether Solver mode is enabled
+
+	undo []undoState // Board before each player move, newest last (see saveUndo/Undo)
+
+	moves    []ReplayMove // Every move made so far, saved as the replay when the game ends (replay.go)
+	started  time.Time    // When the game was created, move times are counted from here
+	aiMoving bool         // Set while an AI move function runs so its moves are recorded as the AI's
+
+	onChange func() // Called after a click changes the board so the front-end can redraw (set by ui-handler/tui-handler)
+}
+
+// Copy of everything a player move can change so the move can be taken back
+type undoState struct {
+	board      [][]Square
+	firstClick bool
+	gameOver   bool
+	win        bool
+	lives      int
 }
 
 // This function creates the game board equipped with mines and numbered squares
 // Inputs: numMines as an int to place on the board
 // Outputs: A gamehandler struct so you can adjust/look at the board
 func NewGameHandler(numMines int) Gamehandler {
+	return NewSeededGameHandler(numMines, time.Now().UnixNano())
+}
+
+// This function creates the game board the same way as NewGameHandler but from a fixed seed (used for scripted games)
+// Inputs: numMines as an int to place on the board and the seed for the bomb placement
+// Outputs: A gamehandler struct so you can adjust/look at the board
+func NewSeededGameHandler(numMines int, seed int64) Gamehandler {
+	handler := NewSizedGameHandler(config.Current.Rows, config.Current.Cols, numMines, seed)
+	handler.setCellMines(config.Current.CellMines)
+	handler.setLives(config.Current.Lives)
+	handler.setTopology(topologyNamed(config.Current.Grid))
+	return handler
+}
+
+// This function creates a game board of any size from a fixed seed on the square grid with at most one bomb per
+// square, the first click policy and question marks come from the settings
+// Inputs: rows/cols of the board, numMines as an int to place on the board (at most one less than the number of cells)
+// and the seed for the bomb placement
+// Outputs: A gamehandler struct so you can adjust/look at the board
+func NewSizedGameHandler(rows int, cols int, numMines int, seed int64) Gamehandler {
+	numMines = min(numMines, rows*cols-1)
 	handler := Gamehandler{}
-	handler.board = make([][]Square, config.BoardSize)
-	handler.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	handler.rows = rows
+	handler.cols = cols
+	handler.firstClickPolicy = config.Current.FirstClick
+	handler.questionMarks = config.Current.QuestionMarks
+	handler.topology = squareTopology{}
+	handler.cellMines = 1
+	handler.setLives(1)
+	handler.board = make([][]Square, rows)
+	handler.rng = rand.New(rand.NewSource(seed))
+	handler.seed = seed
 	handler.firstClick = true
 	handler.gameOver = false
 	handler.win = false
 	handler.totalMines = numMines
+	handler.started = time.Now()
 
-	for x := 0; x < config.BoardSize; x++ {
-		handler.board[x] = make([]Square, config.BoardSize)
+	for x := 0; x < handler.rows; x++ {
+		handler.board[x] = make([]Square, cols)
 	}
 
 	// Iterate through and initialize a square struct for each index in the array
-	for row := 0; row < config.BoardSize; row++ {
-		for col := 0; col < config.BoardSize; col++ {
+	for row := 0; row < handler.rows; row++ {
+		for col := 0; col < handler.cols; col++ {
 			var box Square
 			box.state = Covered
 			handler.board[row][col] = box
 		}
 	}
 
+	handler.placeMines(numMines)
+
+	// Called to adjust the "neighbor numbers" of each cell
+	handler.AddNumbers()
+
+	return handler
+}
+
+// Puts the bombs on random cells of an empty board using the handler's rng, a cell is listed once for every bomb it
+// can hold so in the multi-mine game the same cell can come up more than once
+// Inputs: gameHandler object and the number of bombs
+// Outputs: None, changes the board (the numbers are not worked out)
+func (handler *Gamehandler) placeMines(numMines int) {
 	// represents the total number of cells
-	num_cells := config.BoardSize * config.BoardSize
+	num_cells := handler.rows * handler.cols
 
 	// this slice will have all locations where mines can go
-	possible_mine_locations := make([]int, 0, num_cells)
+	possible_mine_locations := make([]int, 0, num_cells*handler.cellMines)
 
 	// this for-loop finds every cell that is not the first clicked cell
 	// and adds it to the list of possible mine locations
-	for row := 0; row < config.BoardSize; row++ {
-		for col := 0; col < config.BoardSize; col++ {
+	for row := 0; row < handler.rows; row++ {
+		for col := 0; col < handler.cols; col++ {
 			// find current cell
-			cell_id := row*config.BoardSize + col
-			possible_mine_locations = append(possible_mine_locations, cell_id)
+			cell_id := row*handler.cols + col
+			for k := 0; k < handler.cellMines; k++ {
+				possible_mine_locations = append(possible_mine_locations, cell_id)
+			}
 		}
 	}
 
@@ -139,40 +271,28 @@ func NewGameHandler(numMines int) Gamehandler {
 		cell_id := possible_mine_locations[i]
 
 		// convert cell id to row, column
-		row := cell_id / config.BoardSize
-		col := cell_id % config.BoardSize
+		row := cell_id / handler.cols
+		col := cell_id % handler.cols
 
 		// add a mine to the cell
-		handler.board[row][col].isBomb = true
+		handler.board[row][col].bombs++
 	}
-
-	// Called to adjust the "neighbor numbers" of each cell
-	handler.AddNumbers()
-
-	return handler
 }
 
 // Function that iterates through the game board and counts all nearby cells and sees how many bombs there are and sets it's numValue equal to that
 // Inputs: handler object containing the game board
 // Outputs: None, adjusts the underlining handler object
 func (handler *Gamehandler) AddNumbers() {
-	// For each square in the array, count the number of mines in the surrounding eight squares
-	for row := 0; row < config.BoardSize; row++ {
-		for col := 0; col < config.BoardSize; col++ {
-			if handler.board[row][col].isBomb {
+	// For each square in the array, count the number of mines in the neighboring squares
+	for row := 0; row < handler.rows; row++ {
+		for col := 0; col < handler.cols; col++ {
+			if handler.board[row][col].isBomb() {
 				handler.board[row][col].numValue = 0
 				continue
 			}
 			bombc := 0
-			for i := -1; i < 2; i++ {
-				for j := -1; j < 2; j++ {
-					if (i == 0 && j == 0) || !isiInbounds(handler, row+i, col+j) {
-						continue
-					}
-					if handler.board[row+i][col+j].isBomb {
-						bombc += 1
-					}
-				}
+			for _, n := range handler.neighbors(row, col) {
+				bombc += handler.board[n.r][n.c].bombs
 			}
 			handler.board[row][col].numValue = bombc
 		}
@@ -183,7 +303,7 @@ func (handler *Gamehandler) AddNumbers() {
 // Inputs: Row/Col and handler object for game board
 // Outputs: Bool value representing if in bounds
 func isiInbounds(handler *Gamehandler, row int, col int) bool {
-	return (row >= 0) && (row < config.BoardSize) && (col >= 0) && (col < config.BoardSize)
+	return (row >= 0) && (row < handler.rows) && (col >= 0) && (col < handler.cols)
 }
 
 // Helper function to get the board of the handler object specifically
@@ -198,7 +318,7 @@ func GetBoard(handler *Gamehandler) [][]Square {
 // Outputs: None, updates state on the square
 func (handler *Gamehandler) RevealZero(row int, col int) {
 	// Checks to see if coordinate is inside the board if not returns
-	if row < 0 || row >= config.BoardSize || col < 0 || col >= config.BoardSize {
+	if row < 0 || row >= handler.rows || col < 0 || col >= handler.cols {
 		return
 	}
 
@@ -210,7 +330,7 @@ func (handler *Gamehandler) RevealZero(row int, col int) {
 	}
 
 	// If value is zero and not a bomb uncover
-	if sq.numValue == 0 && !sq.isBomb {
+	if sq.numValue == 0 && !sq.isBomb() {
 		sq.state = Uncovered
 	} else {
 		sq.state = Uncovered
@@ -219,29 +339,54 @@ func (handler *Gamehandler) RevealZero(row int, col int) {
 
 	// Recursively calls neighboring squares
 	if sq.numValue == 0 {
-		handler.RevealZero(row+1, col+1)
-		handler.RevealZero(row+1, col-1)
-		handler.RevealZero(row-1, col+1)
-		handler.RevealZero(row-1, col-1)
+		for _, n := range handler.neighbors(row, col) {
+			handler.RevealZero(n.r, n.c)
+		}
+	}
+}
 
-		handler.RevealZero(row-1, col)
-		handler.RevealZero(row+1, col)
-		handler.RevealZero(row, col+1)
-		handler.RevealZero(row, col-1)
+// Reveals a cell and records the move for the replay, a first click also records where the mines ended up after the
+// first click policy moved them
+// Inputs: Row/Col and game handler object
+// Outputs: None
+func (handler *Gamehandler) Click(row, col int) {
+	if handler.gameOver || !isiInbounds(handler, row, col) {
+		return
+	}
+	first := handler.firstClick
+	covered := handler.board[row][col].isCovered()
+	handler.record(MoveReveal, row, col)
+	handler.click(row, col)
+	if first {
+		handler.moves[len(handler.moves)-1].Layout = mineLayout(handler)
+	}
+	if covered && handler.board[row][col].state == Uncovered {
+		handler.usedMove()
+	}
+	if handler.hotseat != nil {
+		handler.endTurn()
 	}
 }
 
 // Function that handles everything the click needs to do from first click safety to bomb discovered to calling recursive flood function/win condition
 // Inputs: Row/Col and game handler object
 // Outputs: None, ensures proper representation on the 2D-array as well as ending the game if need be by calling win codition
-func (handler *Gamehandler) Click(row, col int) {
+func (handler *Gamehandler) click(row, col int) {
 	if handler.gameOver || !isiInbounds(handler, row, col) {
 		return
 	}
 
-	// First-click safety: if first click hits a bomb, move it elsewhere and recompute numbers.
-	if handler.firstClick && handler.board[row][col].isBomb {
-		handler.moveBombFrom(row, col)
+	// First-click safety: if first click hits a bomb, move it elsewhere and recompute numbers (unless the settings say not to)
+	if handler.firstClick {
+		switch handler.firstClickPolicy {
+		case config.FirstClickNone:
+		case config.FirstClickOpening:
+			handler.clearOpening(row, col)
+		default:
+			if handler.board[row][col].isBomb() {
+				handler.moveBombFrom(row, col)
+			}
+		}
 	}
 	handler.firstClick = false
 
@@ -249,7 +394,20 @@ func (handler *Gamehandler) Click(row, col int) {
 	if sq.state == Flagged || sq.state == Uncovered {
 		return
 	}
-	if sq.isBomb {
+	if handler.infinite != nil {
+		handler.revealInfinite(row, col)
+		if handler.onChange != nil {
+			handler.onChange()
+		}
+		return
+	}
+	if sq.isBomb() {
+		if handler.hitMine(row, col) {
+			if handler.onChange != nil {
+				handler.onChange()
+			}
+			return
+		}
 		// lose
 		handler.gameOver = true
 		handler.win = false
@@ -262,52 +420,253 @@ func (handler *Gamehandler) Click(row, col int) {
 	} else {
 		sq.state = Uncovered
 	}
-	UpdateGameUI(handler)
+	if handler.onChange != nil {
+		handler.onChange()
+	}
 	handler.checkWin()
 }
 
-// ToggleFlag flips flag state and checks win.
+// ToggleFlag flips flag state and checks win. With question marks on a flag becomes a question mark before going back
+// to covered, and when cells can hold several mines a flag becomes two flags and so on before that
 // Inputs: row/col and gamehandler object
 // Outputs: Nothing just edits the flagged state
 func (handler *Gamehandler) ToggleFlag(row, col int) {
+	if handler.gameOver || !isiInbounds(handler, row, col) || handler.board[row][col].state == Uncovered {
+		return
+	}
+	handler.record(MoveFlag, row, col)
+	handler.toggleFlag(row, col)
+}
+
+// Does the flag state change for ToggleFlag and Flag without recording it
+func (handler *Gamehandler) toggleFlag(row, col int) {
+	sq := &handler.board[row][col]
+	switch sq.state {
+	case Covered:
+		sq.state, sq.flags = Flagged, 1
+	case Flagged:
+		if sq.flags < handler.cellMines {
+			sq.flags++
+		} else if handler.questionMarks {
+			sq.state, sq.flags = Questioned, 0
+		} else {
+			sq.state, sq.flags = Covered, 0
+		}
+	case Questioned:
+		sq.state = Covered
+	}
+	if handler.infinite != nil {
+		handler.keepFlag(row, col)
+	}
+	if handler.aiEnabled {
+		handler.aiTurn = true
+	}
+	handler.checkWin()
+}
+
+// Flag puts a flag on a square, a question mark is replaced by the flag instead of being cycled like ToggleFlag does.
+// A flagged square gets one more flag if it can hold more mines, a full one is left alone
+// Inputs: row/col and gamehandler object
+// Outputs: Nothing just edits the flagged state
+func (handler *Gamehandler) Flag(row, col int) {
 	if handler.gameOver || !isiInbounds(handler, row, col) {
 		return
 	}
 	sq := &handler.board[row][col]
-	if sq.state == Uncovered {
+	if !sq.isCovered() && (sq.state != Flagged || sq.flags >= handler.cellMines) {
 		return
 	}
-	if sq.state == Flagged {
+	handler.record(MovePlaceFlag, row, col)
+	if sq.state != Flagged {
 		sq.state = Covered
-		if handler.aiEnabled {
-			handler.aiTurn = true
+	}
+	handler.toggleFlag(row, col)
+}
+
+// Counts how many flags are on the board right now
+// Inputs: gameHandler object
+// Outputs: Number of flags (a square with two flags counts twice)
+func (handler *Gamehandler) flagCount() int {
+	flags := 0
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if handler.board[r][c].state == Flagged {
+				flags += handler.board[r][c].flags
+			}
 		}
-	} else {
-		sq.state = Flagged
-		if handler.aiEnabled {
-			handler.aiTurn = true
+	}
+	return flags
+}
+
+// Counts the flags on the neighbors of a cell, a bomb that already went off (lives games) is known so it counts as
+// flagged
+// Inputs: gameHandler object and row/col of the cell
+// Outputs: Number of flags
+func (handler *Gamehandler) flagsAround(row, col int) int {
+	if handler.infinite != nil {
+		return handler.infinite.flagsAround(handler.infinite.top+row, handler.infinite.left+col)
+	}
+	flags := 0
+	for _, n := range handler.neighbors(row, col) {
+		sq := handler.board[n.r][n.c]
+		if sq.state == Flagged {
+			flags += sq.flags
+		} else if sq.exploded {
+			flags += sq.bombs
 		}
 	}
-	handler.checkWin()
+	return flags
 }
 
-// Function that relocates a bomb at (row,col) to the first safe non-bomb cell and re-runs AddNumbers.
+// Remembers the board as it is now, front-ends call this right before a player move (AI moves are undone along with the player move before them)
+// Inputs: gameHandler object
+// Outputs: None, adds to the undo list
+func (handler *Gamehandler) saveUndo() {
+	board := make([][]Square, len(handler.board))
+	for r := range handler.board {
+		board[r] = append([]Square(nil), handler.board[r]...)
+	}
+	handler.undo = append(handler.undo, undoState{
+		board:      board,
+		firstClick: handler.firstClick,
+		gameOver:   handler.gameOver,
+		win:        handler.win,
+		lives:      handler.lives,
+	})
+}
+
+// Forgets the last saved board, used when the move turned out to do nothing
+func (handler *Gamehandler) dropUndo() {
+	if len(handler.undo) > 0 {
+		handler.undo = handler.undo[:len(handler.undo)-1]
+	}
+}
+
+// Puts the board back to how it was before the last player move (this also takes back a loss)
+// Inputs: gameHandler object
+// Outputs: Bool, false if there was nothing to undo
+func (handler *Gamehandler) Undo() bool {
+	// The daily challenge is one attempt and the score modes would get their time or moves back, their moves can't be
+	// taken back
+	if len(handler.undo) == 0 || handler.aiTurn || handler.daily != "" || handler.scored() || handler.hotseat != nil {
+		return false
+	}
+	last := handler.undo[len(handler.undo)-1]
+	handler.undo = handler.undo[:len(handler.undo)-1]
+	handler.board = last.board
+	handler.firstClick = last.firstClick
+	handler.gameOver = last.gameOver
+	handler.win = last.win
+	handler.lives = last.lives
+	handler.record(MoveUndo, 0, 0)
+	return true
+}
+
+// Chord reveals every covered neighbor of an uncovered number, but only once the number has exactly that many flags around it
+// Inputs: row/col of the number cell and gamehandler object
+// Outputs: Bool that is true if at least one neighbor was clicked (a wrong flag can still lose the game here)
+func (handler *Gamehandler) Chord(row, col int) bool {
+	if handler.gameOver || !isiInbounds(handler, row, col) {
+		return false
+	}
+	sq := handler.board[row][col]
+	if sq.state != Uncovered || sq.numValue == 0 {
+		return false
+	}
+
+	// Count the flags around the number first, chording is only allowed when they match
+	if handler.flagsAround(row, col) != sq.numValue {
+		return false
+	}
+	handler.record(MoveChord, row, col)
+	if handler.infinite != nil {
+		return handler.chordInfinite(row, col)
+	}
+	if handler.hotseat != nil {
+		defer handler.endTurn()
+	}
+
+	clicked := false
+	for _, n := range handler.neighbors(row, col) {
+		if handler.board[n.r][n.c].isCovered() {
+			handler.click(n.r, n.c)
+			clicked = true
+		}
+	}
+	if clicked {
+		handler.usedMove()
+	}
+	return clicked
+}
+
+// Function that relocates the bombs at (row,col) to the first cells with room for them and re-runs AddNumbers.
 // Inputs: gameHandler object and row/col
 // Outputs: Nothing just regenerates board into a safe "first-click" state
 func (handler *Gamehandler) moveBombFrom(row, col int) {
-	handler.board[row][col].isBomb = false
+	moving := handler.board[row][col].bombs
+	handler.board[row][col].bombs = 0
 
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
-			if (r == row && c == col) || handler.board[r][c].isBomb {
+	for r := 0; r < handler.rows && moving > 0; r++ {
+		for c := 0; c < handler.cols && moving > 0; c++ {
+			if r == row && c == col {
 				continue
 			}
-			handler.board[r][c].isBomb = true
-			handler.AddNumbers()
-			return
+			room := min(handler.cellMines-handler.board[r][c].bombs, moving)
+			if room > 0 {
+				handler.board[r][c].bombs += room
+				moving -= room
+			}
+		}
+	}
+	handler.board[row][col].bombs += moving // Only if no other cell had room
+	handler.AddNumbers()
+}
+
+// Function that moves every bomb on the clicked cell and its neighbors to random cells outside that area so the first
+// click always opens up an area. If the board is too full for that it falls back to only making the clicked cell safe
+// Inputs: gameHandler object and row/col of the first click
+// Outputs: Nothing just regenerates board into a safe "first-click" state
+func (handler *Gamehandler) clearOpening(row, col int) {
+	opening := append(handler.neighbors(row, col), hardCell{row, col})
+	inOpening := func(r, c int) bool {
+		return slices.Contains(opening, hardCell{r, c})
+	}
+
+	// Bombs that have to move and the free places they can move to, a cell is listed once for every bomb it still has
+	// room for
+	moving := 0
+	free := []int{}
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if inOpening(r, c) {
+				moving += handler.board[r][c].bombs
+				continue
+			}
+			for k := handler.board[r][c].bombs; k < handler.cellMines; k++ {
+				free = append(free, r*handler.cols+c)
+			}
+		}
+	}
+	if moving == 0 {
+		return
+	}
+	if moving > len(free) {
+		if handler.board[row][col].isBomb() {
+			handler.moveBombFrom(row, col)
+		}
+		return
+	}
+
+	handler.rng.Shuffle(len(free), func(i int, j int) {
+		free[i], free[j] = free[j], free[i]
+	})
+	for _, n := range opening {
+		for ; handler.board[n.r][n.c].bombs > 0; handler.board[n.r][n.c].bombs-- {
+			cell_id := free[0]
+			free = free[1:]
+			handler.board[cell_id/handler.cols][cell_id%handler.cols].bombs++
 		}
 	}
-	handler.board[row][col].isBomb = true
 	handler.AddNumbers()
 }
 
@@ -315,10 +674,10 @@ func (handler *Gamehandler) moveBombFrom(row, col int) {
 // Inputs: gameHandler object
 // Outputs: None, just edits the board
 func (handler *Gamehandler) revealAllBombs() {
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
-			if handler.board[r][c].isBomb {
-				handler.board[r][c].state = Uncovered
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if handler.board[r][c].isBomb() {
+				handler.board[r][c].state, handler.board[r][c].flags = Uncovered, 0
 			}
 		}
 	}
@@ -329,16 +688,16 @@ func (handler *Gamehandler) revealAllBombs() {
 // Inputs: gameHandler Object to check board
 // Outputs: checks to see if the game is in a win/lost state and edits that if needed
 func (handler *Gamehandler) checkWin() {
-	if handler.gameOver {
+	if handler.gameOver || handler.infinite != nil { // An infinite board can't be cleared
 		return
 	}
 	flags := 0
 	allNonBombsUncovered := true
 
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
 			sq := handler.board[r][c]
-			if sq.isBomb {
+			if sq.isBomb() {
 				if sq.state == Flagged {
 					flags++
 				}
@@ -358,6 +717,48 @@ func (handler *Gamehandler) checkWin() {
 * AI Addition
  */
 
+// Where the AI progress messages are written, the terminal front-end swaps this out so it doesn't draw over the board
+var aiLog io.Writer = os.Stdout
+
+// Creates a new game like this one: same board size, mine count, mode and AI difficulty but new bomb placement
+// Inputs: gameHandler object of the finished (or abandoned) game
+// Outputs: The new game handler
+func (handler *Gamehandler) rematch() Gamehandler {
+	if handler.infinite != nil {
+		return newInfiniteHandler(time.Now().UnixNano())
+	}
+	h := NewSizedGameHandler(handler.rows, handler.cols, handler.totalMines, time.Now().UnixNano())
+	if handler.layout != nil {
+		h, _ = NewLayoutGameHandler(handler.layout) // Already checked when the game was made
+	}
+	if handler.puzzle != nil {
+		h, _ = NewPuzzleHandler(handler.puzzle.pack, handler.puzzle.index)
+	}
+	if handler.aiEnabled {
+		h.setAIEnabled(true)
+	} else if handler.aiSolver {
+		h.setSolverEnabled(true)
+	}
+	h.aiDifficulty = handler.aiDifficulty
+	if handler.cellMines > 1 {
+		h.setCellMines(handler.cellMines)
+	}
+	h.setLives(handler.startLives)
+	h.setTimeLimit(handler.timeLimit)
+	h.setMoveLimit(handler.moveLimit)
+	h.setTopology(handler.topology)
+	if handler.hotseat != nil {
+		h.setHotseat(handler.hotseat.names)
+		h.hotseat.wins = handler.hotseat.wins
+	}
+	return h
+}
+
+// How long the solver waits between its moves so the user can follow along (the AI delay from the settings)
+func solverDelay() time.Duration {
+	return time.Duration(max(config.Current.AIDelayMs, 1)) * time.Millisecond
+}
+
 // Zhang: enabled AI functions (temp)
 func (handler *Gamehandler) setAIEnabled(enabled bool) {
 	handler.aiEnabled = enabled
@@ -369,42 +770,32 @@ func (handler *Gamehandler) setSolverEnabled(enabled bool) {
 	handler.aiTurn = false
 }
 
-// Zhang: helper function for AI to take it move
-func (handler *Gamehandler) RunAIMove() {
+// Zhang: helper function for AI to take it move, one move per call. The front-ends pace the solver's moves with
+// solverDelay between the calls so the board is redrawn after each one
+// Inputs: gameHandler object
+// Outputs: Bool, false if the game is over or the AI found no move to make
+func (handler *Gamehandler) RunAIMove() bool {
 	if handler.gameOver {
-		return
+		return false
 	}
-	if handler.aiSolver && !handler.gameOver {
-		fmt.Println("AI mode:", handler.aiDifficulty, "started.")
+	if handler.aiSolver {
+		fmt.Fprintln(aiLog, "AI Solver making a move...")
 	}
+	return handler.aiStep()
+}
+
+// Makes a single move for the selected difficulty, RunAIMove without the log line (the terminal front-end uses it so
+// nothing is printed over the board)
+// Inputs: gameHandler object
+// Outputs: Bool from the AI move function (false if no move was made)
+func (handler *Gamehandler) aiStep() bool {
 	switch handler.aiDifficulty {
 	case "Easy":
-		if handler.aiSolver {
-			fmt.Println("AI Solver making a move...")
-			EasyAIMove(handler)
-			time.Sleep(500 * time.Millisecond) // Pause for half a second between moves
-		} else {
-			EasyAIMove(handler)
-		}
+		return EasyAIMove(handler)
 	case "Medium":
-		if handler.aiSolver {
-			for !handler.gameOver {
-				fmt.Println("AI Solver making a move...")
-				MediumAIMove(handler)
-				time.Sleep(500 * time.Millisecond) // Pause for half a second between moves
-			}
-		} else {
-			MediumAIMove(handler)
-		}
+		return MediumAIMove(handler)
 	case "Hard":
-		if handler.aiSolver {
-			for !handler.gameOver {
-				fmt.Println("AI Solver making a move...")
-				HardAIMove(handler)
-				time.Sleep(500 * time.Millisecond) // Pause for half a second between moves
-			}
-		} else {
-			HardAIMove(handler)
-		}
+		return HardAIMove(handler)
 	}
+	return false
 }
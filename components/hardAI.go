@@ -7,8 +7,8 @@ package components
 
 import (
 	"math/rand"
-	"time"
 	"minesweeper/config"
+	"time"
 )
 
 // Local cell struct for AI bookkeeping
@@ -23,6 +23,8 @@ func HardAIMove(handler *Gamehandler) bool {
 	if handler == nil || handler.gameOver { 
 		return false
 	}
+	handler.aiMoving = true // Moves made from here on are recorded as the AI's (replay.go)
+	defer func() { handler.aiMoving = false }()
 
 	// RNG initialization
 	var rng *rand.Rand
@@ -33,13 +35,13 @@ func HardAIMove(handler *Gamehandler) bool {
 	}
 
 	// Collect covered and number cells
-	coveredCells := make([]hardCell, 0, config.BoardSize*config.BoardSize)//hidden
-	numberCells := make([]hardCell, 0, config.BoardSize*config.BoardSize) //uncovered
+	coveredCells := make([]hardCell, 0, handler.rows*handler.cols)//hidden
+	numberCells := make([]hardCell, 0, handler.rows*handler.cols) //uncovered
 
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
 			sq := &handler.board[r][c]
-			if sq.state == Covered {
+			if sq.isCovered() {
 				coveredCells = append(coveredCells, hardCell{r, c})
 			} else if sq.state == Uncovered && sq.numValue > 0 {
 				numberCells = append(numberCells, hardCell{r, c})
@@ -54,90 +56,112 @@ func HardAIMove(handler *Gamehandler) bool {
 
 	// count flagged neighbor cells
 	for _, nc := range numberCells {
-		neighbors := getCoveredNeighbors(handler, nc)
-		if len(neighbors) == 0 {
-			continue
-		}
-
-		// Count already placed flags
-		flagCount := 0
+		// Neighbors that can still take a flag and how many flags they can take, when cells hold one mine these are
+		// just the covered neighbors
+		room := 0
+		open := make([]hardCell, 0, 8)
 		for _, neigh := range getAllNeighbors(handler, nc) {
-			if handler.board[neigh.r][neigh.c].state == Flagged {
-				flagCount++
+			if n := handler.flagRoom(neigh.r, neigh.c); n > 0 {
+				room += n
+				open = append(open, neigh)
 			}
 		}
+		if len(open) == 0 {
+			continue
+		}
+		neighbors := getCoveredNeighbors(handler, nc)
+
+		// Count already placed flags (a cell with two flags counts twice)
+		flagCount := handler.flagsAround(nc.r, nc.c)
 
 		// Step 1: All remaining covered neighbors are safe if num == flagcount
-		if handler.board[nc.r][nc.c].numValue == flagCount {
+		if handler.board[nc.r][nc.c].numValue == flagCount && len(neighbors) > 0 {
 			move := neighbors[rng.Intn(len(neighbors))]
 			handler.board[move.r][move.c].markedByAI = true
 			handler.Click(move.r, move.c)
 			return true
 		}
 
-		// Step 2: All covered neighbors are bombs if num == flagcount + hidden
-		if handler.board[nc.r][nc.c].numValue == flagCount+len(neighbors) {
-			move := neighbors[rng.Intn(len(neighbors))]
+		// Step 2: A covered neighbor has another bomb if the others can't hold all the bombs still missing, on the
+		// classic board that is when num == flagcount + hidden and then every covered neighbor is a bomb
+		missing := handler.board[nc.r][nc.c].numValue - flagCount
+		certain := make([]hardCell, 0, len(open))
+		for _, neigh := range open {
+			if missing <= room && missing > room-handler.flagRoom(neigh.r, neigh.c) {
+				certain = append(certain, neigh)
+			}
+		}
+		if len(certain) > 0 {
+			move := certain[rng.Intn(len(certain))]
 			handler.board[move.r][move.c].markedByAI = true
-			handler.ToggleFlag(move.r, move.c)
+			handler.Flag(move.r, move.c)
 			return true
 		}
 	}
 
-	//  1-2-1 pattern rule 
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize-2; c++ {
-			// Look for horizontally adjacent 1-2-1
-			if handler.board[r][c].state == Uncovered &&
-				handler.board[r][c+1].state == Uncovered &&
-				handler.board[r][c+2].state == Uncovered &&
-				handler.board[r][c].numValue == 1 &&
-				handler.board[r][c+1].numValue == 2 &&
-				handler.board[r][c+2].numValue == 1 {
-
-				// Collect the cells above/below these three
-				top := []hardCell{}
-				bottom := []hardCell{}
-
-				if r > 0 {
-					// Above row
-					if handler.board[r-1][c].state == Covered {
-						top = append(top, hardCell{r - 1, c})
-					}
-					if handler.board[r-1][c+1].state == Covered {
-						top = append(top, hardCell{r - 1, c + 1})
-					}
-					if handler.board[r-1][c+2].state == Covered {
-						top = append(top, hardCell{r - 1, c + 2})
+	//  1-2-1 pattern rule (it reads the rows above and below the three numbers, so only on the square grid, and a
+	// cell with two mines breaks it)
+	if handler.gridName() == config.GridSquare && handler.cellMines == 1 {
+		for r := 0; r < handler.rows; r++ {
+			for c := 0; c < handler.cols-2; c++ {
+				// Look for horizontally adjacent 1-2-1
+				if handler.board[r][c].state == Uncovered &&
+					handler.board[r][c+1].state == Uncovered &&
+					handler.board[r][c+2].state == Uncovered &&
+					handler.board[r][c].numValue == 1 &&
+					handler.board[r][c+1].numValue == 2 &&
+					handler.board[r][c+2].numValue == 1 {
+
+					// Collect the cells above/below these three
+					top := []hardCell{}
+					bottom := []hardCell{}
+
+					if r > 0 {
+						// Above row
+						if handler.board[r-1][c].isCovered() {
+							top = append(top, hardCell{r - 1, c})
+						}
+						if handler.board[r-1][c+1].isCovered() {
+							top = append(top, hardCell{r - 1, c + 1})
+						}
+						if handler.board[r-1][c+2].isCovered() {
+							top = append(top, hardCell{r - 1, c + 2})
+						}
 					}
-				}
 
-				if r < config.BoardSize-1 {
-					// Below row
-					if handler.board[r+1][c].state == Covered {
-						bottom = append(bottom, hardCell{r + 1, c})
-					}
-					if handler.board[r+1][c+1].state == Covered {
-						bottom = append(bottom, hardCell{r + 1, c + 1})
+					if r < handler.rows-1 {
+						// Below row
+						if handler.board[r+1][c].isCovered() {
+							bottom = append(bottom, hardCell{r + 1, c})
+						}
+						if handler.board[r+1][c+1].isCovered() {
+							bottom = append(bottom, hardCell{r + 1, c + 1})
+						}
+						if handler.board[r+1][c+2].isCovered() {
+							bottom = append(bottom, hardCell{r + 1, c + 2})
+						}
 					}
-					if handler.board[r+1][c+2].state == Covered {
-						bottom = append(bottom, hardCell{r + 1, c + 2})
-					}
-				}
 
-				// Apply 1-2-1 logic (check top first, then bottom)
-				if len(top) == 3 {
-					// Flag the two outer cells
-					handler.ToggleFlag(top[0].r, top[0].c)
-					handler.ToggleFlag(top[2].r, top[2].c)
-					// Click the safe middle cell
-					handler.Click(top[1].r, top[1].c)
-					return true
-				} else if len(bottom) == 3 {
-					handler.ToggleFlag(bottom[0].r, bottom[0].c)
-					handler.ToggleFlag(bottom[2].r, bottom[2].c)
-					handler.Click(bottom[1].r, bottom[1].c)
-					return true
+					// Apply 1-2-1 logic (check top first, then bottom)
+					if len(top) == 3 {
+						for _, cell := range top {
+							handler.board[cell.r][cell.c].markedByAI = true
+						}
+						// Flag the two outer cells
+						handler.Flag(top[0].r, top[0].c)
+						handler.Flag(top[2].r, top[2].c)
+						// Click the safe middle cell
+						handler.Click(top[1].r, top[1].c)
+						return true
+					} else if len(bottom) == 3 {
+						for _, cell := range bottom {
+							handler.board[cell.r][cell.c].markedByAI = true
+						}
+						handler.Flag(bottom[0].r, bottom[0].c)
+						handler.Flag(bottom[2].r, bottom[2].c)
+						handler.Click(bottom[1].r, bottom[1].c)
+						return true
+					}
 				}
 			}
 		}
@@ -153,38 +177,15 @@ func HardAIMove(handler *Gamehandler) bool {
 // getCoveredNeighbors returns covered neighbors of a given number cell
 func getCoveredNeighbors(handler *Gamehandler, nc hardCell) []hardCell {
 	neighbors := make([]hardCell, 0, 8)
-	for dr := -1; dr <= 1; dr++ {
-		for dc := -1; dc <= 1; dc++ {
-			if dr == 0 && dc == 0 {
-				continue
-			}
-			nr, nc2 := nc.r+dr, nc.c+dc
-			if isInBounds(handler, nr, nc2) && handler.board[nr][nc2].state == Covered {
-				neighbors = append(neighbors, hardCell{nr, nc2})
-			}
+	for _, n := range handler.neighbors(nc.r, nc.c) {
+		if handler.board[n.r][n.c].isCovered() {
+			neighbors = append(neighbors, n)
 		}
 	}
 	return neighbors
 }
 
-// getAllNeighbors returns all neighbors of a given number cell
+// getAllNeighbors returns all neighbors of a given number cell (which ones depends on the board's topology)
 func getAllNeighbors(handler *Gamehandler, nc hardCell) []hardCell {
-	neighbors := make([]hardCell, 0, 8)
-	for dr := -1; dr <= 1; dr++ {
-		for dc := -1; dc <= 1; dc++ {
-			if dr == 0 && dc == 0 {
-				continue
-			}
-			nr, nc2 := nc.r+dr, nc.c+dc
-			if isInBounds(handler, nr, nc2) {
-				neighbors = append(neighbors, hardCell{nr, nc2})
-			}
-		}
-	}
-	return neighbors
+	return handler.neighbors(nc.r, nc.c)
 }
-
-// isInBounds checks if row/col is in valid bounds
-func isInBounds(handler *Gamehandler, r, c int) bool {
-	return r >= 0 && r < config.BoardSize && c >= 0 && c < config.BoardSize
-}
\ No newline at end of file
@@ -0,0 +1,100 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file works out a hint for the player using only what is visible on the board (the numbers), it does not peek at
+where the bombs are and does not trust the player's flags since those could be wrong
+
+Functions:
+- FindHint: Looks for a covered cell that is certainly safe, otherwise a cell that is certainly a bomb
+
+Inputs:
+- Game handler with the current board
+
+Outputs:
+- Cell for the hint and whether it is safe or a bomb
+*/
+
+package components
+
+// Works out a hint by repeating the two basic rules until nothing changes:
+// a number whose known bombs already match it makes the rest of its covered neighbors safe,
+// a number whose unknown neighbors are exactly what it still needs makes all of them bombs.
+// When cells can hold several mines a bomb cell is known with its mine count: the second rule needs every unknown
+// neighbor to be full, and a number with one unknown neighbor left tells how many mines that cell has
+// Inputs: gameHandler object
+// Outputs: row/col of the hint, true if that cell is safe (false means it is a bomb that needs another flag), and false at the end if there is no certain move
+func FindHint(handler *Gamehandler) (int, int, bool, bool) {
+	bombs := map[hardCell]int{} // Mines in the cells known to be bombs
+	safe := map[hardCell]bool{}
+
+	changed := true
+	for changed {
+		changed = false
+		for r := 0; r < handler.rows; r++ {
+			for c := 0; c < handler.cols; c++ {
+				sq := handler.board[r][c]
+				if sq.state != Uncovered || sq.isBomb() || sq.numValue == 0 {
+					continue
+				}
+
+				// Split the hidden neighbors into known bombs and still unknown cells
+				knownBombs := 0
+				unknown := []hardCell{}
+				for _, n := range getAllNeighbors(handler, hardCell{r, c}) {
+					if handler.board[n.r][n.c].exploded {
+						// A mine that went off in a lives game (lives.go) is a known mine that can't be flagged
+						knownBombs += handler.board[n.r][n.c].bombs
+						continue
+					}
+					if handler.board[n.r][n.c].state == Uncovered {
+						continue
+					}
+					if bombs[n] > 0 {
+						knownBombs += bombs[n]
+					} else if !safe[n] {
+						unknown = append(unknown, n)
+					}
+				}
+				if len(unknown) == 0 {
+					continue
+				}
+
+				if knownBombs == sq.numValue {
+					for _, n := range unknown {
+						safe[n] = true
+					}
+					changed = true
+				} else if knownBombs+len(unknown)*handler.cellMines == sq.numValue {
+					for _, n := range unknown {
+						bombs[n] = handler.cellMines
+					}
+					changed = true
+				} else if len(unknown) == 1 && sq.numValue > knownBombs && sq.numValue-knownBombs <= handler.cellMines {
+					bombs[unknown[0]] = sq.numValue - knownBombs
+					changed = true
+				}
+			}
+		}
+	}
+
+	// A safe cell to reveal is the better hint, go in board order so the same board always gives the same hint
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if safe[hardCell{r, c}] && handler.board[r][c].isCovered() {
+				return r, c, true, true
+			}
+		}
+	}
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if bombs[hardCell{r, c}] > 0 && (handler.board[r][c].state != Flagged || handler.board[r][c].flags < bombs[hardCell{r, c}]) {
+				return r, c, false, true
+			}
+		}
+	}
+	return 0, 0, false, false
+}
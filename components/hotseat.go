@@ -0,0 +1,215 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the hot-seat mode, two people taking turns on one board. A turn is one reveal or chord that uncovers
+something, and the cells it uncovers belong to the player who made it. Hitting a mine loses the game, clearing the
+board wins it for the player with the most cells
+
+Functions:
+- setHotseat: Makes a game a hot-seat game between two players
+
+- playerNames: Checks the names typed on the setup screens
+
+- hotseatOption/hotseatNames: Put the names in a mode option and read them back
+
+- endTurn: Gives the cells a move uncovered to the player who made it and passes the turn (or ends the game)
+
+- waitingForPlayer/startTurn: Whether the next player has to start their turn, and starting it
+
+- cleared: Safe cells a player has uncovered
+
+- hotseatWinner: Which player won, if anyone
+
+- playerText/turnText: Scoreboard and turn lines for the front-ends
+
+Inputs:
+- Player names from the setup screen, moves on the board
+
+Outputs:
+- Whose turn it is, the owner of each uncovered cell, the scoreboard
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+	"strings"
+)
+
+// The players of a hot-seat game and whose turn it is
+type hotseatGame struct {
+	names    [2]string
+	turn     int    // Index of the player whose turn it is (or whose move ended the game)
+	handover bool   // The turn has passed and the next player hasn't started it yet
+	loser    int    // Player who hit the mine, -1 if nobody has
+	wins     [2]int // Games each player won so far, rematch carries these over
+	finished bool   // Whether this game's result was already added to wins
+}
+
+// Makes a game a hot-seat game, the first player starts
+// Inputs: gameHandler object and the players' names
+// Outputs: None, changes the handler
+func (handler *Gamehandler) setHotseat(names [2]string) {
+	handler.hotseat = &hotseatGame{names: names, loser: -1}
+}
+
+// Checks the names typed for the two players (shared by the window and the terminal front-end)
+// Inputs: The names as typed
+// Outputs: The names without spaces around them, or an error meant to be shown to the user
+func playerNames(first string, second string) ([2]string, error) {
+	names := [2]string{strings.TrimSpace(first), strings.TrimSpace(second)}
+	switch {
+	case names[0] == "" || names[1] == "":
+		return names, fmt.Errorf("Both players need a name.")
+	case len([]rune(names[0])) > config.MaxNameLength || len([]rune(names[1])) > config.MaxNameLength:
+		return names, fmt.Errorf("Names can be at most %d letters long.", config.MaxNameLength)
+	case strings.EqualFold(names[0], names[1]):
+		return names, fmt.Errorf("The players need different names.")
+	}
+	return names, nil
+}
+
+// Puts the players' names in a mode option, names can't have a line break in them so it separates them
+// Inputs: Names
+// Outputs: Option for applyMode and the replays
+func hotseatOption(names [2]string) string {
+	return names[0] + "\n" + names[1]
+}
+
+// Reads the players' names back from a mode option, missing names are filled in from the defaults
+// Inputs: Option made by hotseatOption
+// Outputs: Names
+func hotseatNames(option string) [2]string {
+	first, second, _ := strings.Cut(option, "\n")
+	names := [2]string{first, second}
+	for i := range names {
+		if names[i] = strings.TrimSpace(names[i]); names[i] == "" {
+			names[i] = config.DefaultSettings().HotseatNames[i]
+		}
+	}
+	return names
+}
+
+// Ends the move of the player whose turn it is: the cells it uncovered (and a mine that went off in a lives game) are
+// theirs, then the turn goes to the other player if the move uncovered anything. When the move ended the game the
+// result is worked out instead, a mine loses it for the player who hit it. Click and Chord call this after every move
+// Inputs: gameHandler object
+// Outputs: None, changes the handler
+func (handler *Gamehandler) endTurn() {
+	hs := handler.hotseat
+	uncovered := false
+	for r := range handler.board {
+		for c := range handler.board[r] {
+			if sq := &handler.board[r][c]; sq.state == Uncovered && sq.player == 0 && (!sq.isBomb() || sq.exploded) {
+				sq.player = hs.turn + 1
+				uncovered = true
+			}
+		}
+	}
+	if handler.gameOver {
+		if !hs.finished {
+			hs.finished = true
+			if !handler.win {
+				hs.loser = hs.turn
+			}
+			if winner := handler.hotseatWinner(); winner >= 0 {
+				hs.wins[winner]++
+			}
+		}
+		return
+	}
+	if uncovered {
+		hs.turn = 1 - hs.turn
+		hs.handover = true
+	}
+}
+
+// Tells whether the turn has passed and the next player still has to start it, the front-ends don't take moves until
+// they have (the same way they wait for the AI while it is its turn)
+// Inputs: gameHandler object
+// Outputs: Bool, always false for other games
+func (handler *Gamehandler) waitingForPlayer() bool {
+	return handler.hotseat != nil && handler.hotseat.handover && !handler.gameOver
+}
+
+// Lets the player whose turn it is start making moves
+// Inputs: gameHandler object
+// Outputs: None, changes the handler
+func (handler *Gamehandler) startTurn() {
+	if handler.hotseat != nil {
+		handler.hotseat.handover = false
+	}
+}
+
+// Counts the safe cells a player has uncovered
+// Inputs: gameHandler object and the player (0 or 1)
+// Outputs: Number of cells
+func (handler *Gamehandler) cleared(player int) int {
+	cells := 0
+	for r := range handler.board {
+		for c := range handler.board[r] {
+			if sq := handler.board[r][c]; sq.player == player+1 && !sq.isBomb() {
+				cells++
+			}
+		}
+	}
+	return cells
+}
+
+// Works out who won a finished hot-seat game: the other player if a mine was hit, otherwise the one with the most
+// cells
+// Inputs: gameHandler object
+// Outputs: The winner (0 or 1), -1 for a draw or a game that isn't over
+func (handler *Gamehandler) hotseatWinner() int {
+	hs := handler.hotseat
+	if !handler.gameOver {
+		return -1
+	}
+	if hs.loser >= 0 {
+		return 1 - hs.loser
+	}
+	switch first, second := handler.cleared(0), handler.cleared(1); {
+	case first > second:
+		return 0
+	case second > first:
+		return 1
+	}
+	return -1
+}
+
+// Gives a player's line of the scoreboard
+// Inputs: gameHandler object and the player (0 or 1)
+// Outputs: Text such as "Alice: 12 cells, 2 wins"
+func playerText(handler *Gamehandler, player int) string {
+	hs := handler.hotseat
+	text := fmt.Sprintf("%s: %d cells", hs.names[player], handler.cleared(player))
+	if handler.cleared(player) == 1 {
+		text = hs.names[player] + ": 1 cell"
+	}
+	if wins := hs.wins[player]; wins == 1 {
+		text += ", 1 win"
+	} else if wins > 1 {
+		text += fmt.Sprintf(", %d wins", wins)
+	}
+	return text
+}
+
+// Tells whose turn it is, or who won once the game is over
+// Inputs: gameHandler object
+// Outputs: Text such as "Bob's turn" or "Alice wins!"
+func turnText(handler *Gamehandler) string {
+	hs := handler.hotseat
+	switch winner := handler.hotseatWinner(); {
+	case !handler.gameOver:
+		return hs.names[hs.turn] + "'s turn"
+	case winner < 0:
+		return "It's a draw!"
+	default:
+		return hs.names[winner] + " wins!"
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the infinite mode (Infinite on the game mode screen). The board has no edges: it is made of square chunks
+of config.InfiniteChunk cells with config.InfiniteMines mines each, and a chunk is only made once a move reaches it or
+its neighbors. The mines of a chunk come from its own seed, worked out from the game's seed and where the chunk is, so
+the same seed always gives the same board no matter which way the player goes. The cells around the start are kept
+clear and are opened when the game begins.
+The game handler is a window (config.InfiniteView cells across) onto the board: its cells are copied from the chunks
+(syncView) and reveals and chords are done on the chunks so openings carry on past the edge of the window. The window
+scrolls with the arrow buttons above the board, or when the keyboard cursor is moved past its edge. The game ends at the
+first mine and the score is the number of safe cells uncovered before it. Infinite games have no undo, hints, replays or
+save files, their best score is kept in the stats (stats.go)
+
+Functions:
+- newInfiniteHandler: Starts an infinite game from a seed
+
+- chunkSeed: The seed of one chunk
+
+- chunkPos: Which chunk a board row or column is in
+
+- chunk/cell: Finds a chunk or a cell of the board, making the chunk if it isn't there yet
+
+- reveal: Uncovers a cell and opens up the empty cells around it
+
+- revealInfinite/chordInfinite: Reveal and chord moves of an infinite game
+
+- flagsAround: Counts the flags around a cell of the board
+
+- keepFlag: Copies a flag change from the window to the board
+
+- syncView: Copies the cells in the window from the board
+
+- scrollView: Moves the window
+
+Inputs:
+- Seed, moves on the window
+
+Outputs:
+- The part of the board in the window, the score
+*/
+
+package components
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"minesweeper/config"
+)
+
+// Where a chunk is, in chunks from the one the game starts in
+type chunkKey struct{ r, c int }
+
+// The board of an infinite game, rows and columns are counted from the start cell and can be negative
+type chunkBoard struct {
+	seed    int64
+	chunks  map[chunkKey][][]Square // Chunks made so far
+	top     int                     // Board row of the window's top row
+	left    int                     // Board column of the window's left column
+	cleared int                     // Safe cells uncovered, the score
+}
+
+// Starts an infinite game, the window is centered on the start cell and the cells around it are opened
+// Inputs: Seed for the chunks
+// Outputs: gameHandler object showing the window
+func newInfiniteHandler(seed int64) Gamehandler {
+	h := NewSizedGameHandler(config.InfiniteView, config.InfiniteView, 0, seed)
+	h.firstClick = false
+	h.infinite = &chunkBoard{
+		seed:   seed,
+		chunks: map[chunkKey][][]Square{},
+		top:    -config.InfiniteView / 2,
+		left:   -config.InfiniteView / 2,
+	}
+	h.infinite.reveal(0, 0)
+	h.syncView()
+	return h
+}
+
+// Gives the seed of a chunk, made from the game's seed and where the chunk is
+// Inputs: Game seed and the chunk
+// Outputs: Seed for the chunk's mines
+func chunkSeed(seed int64, key chunkKey) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(fmt.Sprintf("minesweeper chunk %d %d %d", seed, key.r, key.c)))
+	return int64(hash.Sum64())
+}
+
+// Splits a board row or column into the chunk it is in and where it is inside that chunk
+func chunkPos(n int) (int, int) {
+	size := config.InfiniteChunk
+	chunk := n / size
+	if n%size < 0 {
+		chunk--
+	}
+	return chunk, n - chunk*size
+}
+
+// Gives a chunk, making it first if it isn't there yet: its mines go on random cells picked with the chunk's seed,
+// leaving out the start cell and its neighbors
+// Inputs: The chunk
+// Outputs: Cells of the chunk
+func (ib *chunkBoard) chunk(key chunkKey) [][]Square {
+	if cells, ok := ib.chunks[key]; ok {
+		return cells
+	}
+	size := config.InfiniteChunk
+	cells := make([][]Square, size)
+	free := make([]int, 0, size*size)
+	for r := range cells {
+		cells[r] = make([]Square, size)
+		for c := range cells[r] {
+			row, col := key.r*size+r, key.c*size+c
+			if row < -1 || row > 1 || col < -1 || col > 1 {
+				free = append(free, r*size+c)
+			}
+		}
+	}
+	rng := rand.New(rand.NewSource(chunkSeed(ib.seed, key)))
+	for _, i := range rng.Perm(len(free))[:min(config.InfiniteMines, len(free))] {
+		cells[free[i]/size][free[i]%size].bombs = 1
+	}
+	ib.chunks[key] = cells
+	return cells
+}
+
+// Gives a cell of the board, making its chunk if needed
+// Inputs: Board row/col
+// Outputs: The cell
+func (ib *chunkBoard) cell(row, col int) *Square {
+	cr, r := chunkPos(row)
+	cc, c := chunkPos(col)
+	return &ib.chunk(chunkKey{cr, cc})[r][c]
+}
+
+// Uncovers a cell, a cell with no mines around it opens its neighbors too (on and on, past the edge of the window).
+// The number of a cell is worked out when it is uncovered, making the chunks next to it if it is on a chunk's edge
+// Inputs: Board row/col
+// Outputs: True if the cell was a mine
+func (ib *chunkBoard) reveal(row, col int) bool {
+	if sq := ib.cell(row, col); sq.isBomb() && sq.isCovered() {
+		sq.state, sq.exploded = Uncovered, true
+		return true
+	}
+	queue := []hardCell{{row, col}}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		sq := ib.cell(next.r, next.c)
+		if !sq.isCovered() || sq.isBomb() {
+			continue
+		}
+		sq.state = Uncovered
+		sq.numValue = 0
+		for _, d := range squareOffsets {
+			sq.numValue += ib.cell(next.r+d[0], next.c+d[1]).bombs
+		}
+		ib.cleared++
+		if sq.numValue == 0 {
+			for _, d := range squareOffsets {
+				queue = append(queue, hardCell{next.r + d[0], next.c + d[1]})
+			}
+		}
+	}
+	return false
+}
+
+// Reveals a cell of the window on the board, hitting a mine ends the game and shows the mines of every chunk made
+// Inputs: gameHandler object and row/col in the window
+// Outputs: None, changes the handler
+func (handler *Gamehandler) revealInfinite(row, col int) {
+	ib := handler.infinite
+	if ib.reveal(ib.top+row, ib.left+col) {
+		handler.gameOver = true
+		handler.win = false
+		for _, cells := range ib.chunks {
+			for r := range cells {
+				for c := range cells[r] {
+					if cells[r][c].isBomb() {
+						cells[r][c].state, cells[r][c].flags = Uncovered, 0
+					}
+				}
+			}
+		}
+	}
+	handler.syncView()
+}
+
+// Counts the flags around a cell of the board, Chord and the hints use this so a number on the edge of the window
+// sees the flags past it
+// Inputs: Board row/col
+// Outputs: Number of flags
+func (ib *chunkBoard) flagsAround(row, col int) int {
+	flags := 0
+	for _, d := range squareOffsets {
+		if sq := ib.cell(row+d[0], col+d[1]); sq.state == Flagged {
+			flags += sq.flags
+		}
+	}
+	return flags
+}
+
+// Reveals the covered neighbors of a number in the window on the board, Chord has already checked the flags
+// Inputs: gameHandler object and row/col in the window
+// Outputs: True if at least one neighbor was revealed
+func (handler *Gamehandler) chordInfinite(row, col int) bool {
+	ib := handler.infinite
+	clicked := false
+	for _, d := range squareOffsets {
+		if sq := ib.cell(ib.top+row+d[0], ib.left+col+d[1]); sq.isCovered() && !handler.gameOver {
+			handler.revealInfinite(row+d[0], col+d[1])
+			clicked = true
+		}
+	}
+	if handler.onChange != nil {
+		handler.onChange()
+	}
+	return clicked
+}
+
+// Copies a flag or question mark put on the window to the board, the board keeps it once the window moves on
+// Inputs: gameHandler object and row/col in the window
+// Outputs: None
+func (handler *Gamehandler) keepFlag(row, col int) {
+	ib := handler.infinite
+	sq := ib.cell(ib.top+row, ib.left+col)
+	sq.state, sq.flags = handler.board[row][col].state, handler.board[row][col].flags
+}
+
+// Copies the cells in the window from the board, parts of the board with no chunk yet are shown covered (the chunk
+// isn't made until a move reaches it)
+// Inputs: gameHandler object
+// Outputs: None, changes the window
+func (handler *Gamehandler) syncView() {
+	ib := handler.infinite
+	for r := range handler.board {
+		for c := range handler.board[r] {
+			cr, row := chunkPos(ib.top + r)
+			cc, col := chunkPos(ib.left + c)
+			if cells, ok := ib.chunks[chunkKey{cr, cc}]; ok {
+				handler.board[r][c] = cells[row][col]
+			} else {
+				handler.board[r][c] = Square{}
+			}
+		}
+	}
+}
+
+// Moves the window over the board
+// Inputs: gameHandler object and how many rows down and columns right to move it (negative for up/left)
+// Outputs: None, changes the window
+func (handler *Gamehandler) scrollView(rows, cols int) {
+	if handler.infinite == nil || (rows == 0 && cols == 0) {
+		return
+	}
+	handler.infinite.top += rows
+	handler.infinite.left += cols
+	handler.syncView()
+}
@@ -0,0 +1,287 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file adds keyboard controls to the Fyne board so the game can be played without a mouse. A cursor is drawn over
+the board and moved with the arrow keys/WASD/hjkl, and there are keys for reveal, flag, chord, undo, hint, restart and
+going back to the title screen (plus zooming the board). Pressing the go-to key and typing a coordinate such as "c4"
+then enter jumps the cursor there. Which keys do what comes from a Keymap, the defaults can be changed on the Keys tab of
+the Settings screen
+
+Functions:
+- DefaultKeymap: The keys used when nothing else has been set
+
+- currentKeymap: The default keys with the ones changed in the settings swapped in
+
+- attachKeys: Sends the window's key events to the board when nothing has focus
+
+- TypedKey: Runs the action bound to the key that was pressed (the board widget is focusable)
+
+- typeCoordinate: Handles keys while a coordinate is being typed in after the go-to key
+
+- showCursor: Shows the cursor on the cursor cell and scrolls it into view
+
+Inputs:
+- Key presses on the game window
+
+Outputs:
+- Cursor movement and the same moves a mouse click would make
+*/
+
+package components
+
+import (
+	"minesweeper/config"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Names of the actions keys can be bound to
+const (
+	ActionUp      = "up"
+	ActionDown    = "down"
+	ActionLeft    = "left"
+	ActionRight   = "right"
+	ActionReveal  = "reveal"
+	ActionFlag    = "flag"
+	ActionChord   = "chord"
+	ActionUndo    = "undo"
+	ActionHint    = "hint"
+	ActionRestart = "restart"
+	ActionTitle   = "title"
+	ActionGoTo    = "goto"
+	ActionZoomIn  = "zoomin"
+	ActionZoomOut = "zoomout"
+)
+
+// Keymap maps each action to the keys that trigger it
+type Keymap map[string][]fyne.KeyName
+
+// Actions in the order the Settings screen lists them, with a readable name for each
+var keyActions = []struct{ action, label string }{
+	{ActionUp, "Move up"},
+	{ActionDown, "Move down"},
+	{ActionLeft, "Move left"},
+	{ActionRight, "Move right"},
+	{ActionReveal, "Reveal"},
+	{ActionFlag, "Flag"},
+	{ActionChord, "Chord"},
+	{ActionUndo, "Undo"},
+	{ActionHint, "Hint"},
+	{ActionRestart, "Restart"},
+	{ActionTitle, "Title screen"},
+	{ActionGoTo, "Go to cell"},
+	{ActionZoomIn, "Zoom in"},
+	{ActionZoomOut, "Zoom out"},
+}
+
+// Gives the default keys (arrows, WASD and hjkl all move the cursor)
+// Inputs: None
+// Outputs: Fresh Keymap the caller can change
+func DefaultKeymap() Keymap {
+	return Keymap{
+		ActionUp:      {fyne.KeyUp, fyne.KeyW, fyne.KeyK},
+		ActionDown:    {fyne.KeyDown, fyne.KeyS, fyne.KeyJ},
+		ActionLeft:    {fyne.KeyLeft, fyne.KeyA, fyne.KeyH},
+		ActionRight:   {fyne.KeyRight, fyne.KeyD, fyne.KeyL},
+		ActionReveal:  {fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter},
+		ActionFlag:    {fyne.KeyF},
+		ActionChord:   {fyne.KeyC},
+		ActionUndo:    {fyne.KeyU},
+		ActionHint:    {fyne.KeySlash},
+		ActionRestart: {fyne.KeyN, fyne.KeyF2},
+		ActionTitle:   {fyne.KeyEscape},
+		ActionGoTo:    {fyne.KeyG},
+		ActionZoomIn:  {fyne.KeyEqual, fyne.KeyPlus},
+		ActionZoomOut: {fyne.KeyMinus},
+	}
+}
+
+// Gives the keys to use: the defaults, with every action that was changed in the settings using the saved keys instead
+// Inputs: None
+// Outputs: Keymap
+func currentKeymap() Keymap {
+	keys := DefaultKeymap()
+	for action, names := range config.Current.Keys {
+		if _, ok := keys[action]; !ok || len(names) == 0 {
+			continue // Unknown action or nothing bound, keep the default
+		}
+		keys[action] = nil
+		for _, name := range names {
+			keys[action] = append(keys[action], fyne.KeyName(name))
+		}
+	}
+	return keys
+}
+
+// Hooks the window's key events up to the board as well, so keys work even when nothing has focus yet
+// Inputs: The game screen holding the board, keys are ignored once the window shows something else
+// Outputs: None
+func (b *BoardWidget) attachKeys(content fyne.CanvasObject) {
+	win := fyne.CurrentApp().Driver().AllWindows()[0]
+	win.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if win.Content() == content {
+			b.TypedKey(ev)
+		}
+	})
+}
+
+// FocusGained is called when the board gets keyboard focus
+func (b *BoardWidget) FocusGained() {}
+
+// FocusLost is called when the board loses keyboard focus
+func (b *BoardWidget) FocusLost() {}
+
+// TypedRune is not used, every key is handled in TypedKey
+func (b *BoardWidget) TypedRune(_ rune) {}
+
+// TypedKey runs whatever action the pressed key is bound to
+func (b *BoardWidget) TypedKey(ev *fyne.KeyEvent) {
+	if b.ReadOnly {
+		return
+	}
+	if b.typing {
+		b.typeCoordinate(ev.Name)
+		return
+	}
+
+	b.status = ""
+	// Between hot-seat turns a move key starts the next player's turn instead, like the button over the board
+	if action := b.actions[ev.Name]; b.handler.waitingForPlayer() && (action == ActionReveal || action == ActionFlag || action == ActionChord) {
+		b.handler.startTurn()
+		UpdateGameUI(b.handler)
+		b.announce(turnText(b.handler))
+		return
+	}
+	switch b.actions[ev.Name] {
+	case ActionUp:
+		b.move(-1, 0)
+	case ActionDown:
+		b.move(1, 0)
+	case ActionLeft:
+		b.move(0, -1)
+	case ActionRight:
+		b.move(0, 1)
+	case ActionReveal:
+		revealCell(b.handler, b.cursorRow, b.cursorCol)
+	case ActionFlag:
+		flagCell(b.handler, b.cursorRow, b.cursorCol)
+	case ActionChord:
+		chordCell(b.handler, b.cursorRow, b.cursorCol)
+	case ActionUndo:
+		if b.handler.Undo() {
+			UpdateGameUI(b.handler)
+		}
+	case ActionHint:
+		if b.handler.infinite != nil {
+			b.announce("No hints on the infinite board") // Numbers on the edge of the window have neighbors out of view
+			break
+		}
+		row, col, safe, ok := FindHint(b.handler)
+		if !ok {
+			b.status = "guess"
+			b.announce("No certain move, you will have to guess")
+		} else {
+			b.cursorRow, b.cursorCol = row, col
+			if safe {
+				b.status = "safe"
+				b.announce("Hint: " + cellName(row, col) + " is safe")
+			} else {
+				b.status = "bomb"
+				if b.handler.cellMines > 1 {
+					b.announce("Hint: " + cellName(row, col) + " has a mine that isn't flagged yet")
+				} else {
+					b.announce("Hint: " + cellName(row, col) + " is a mine")
+				}
+			}
+			b.showCursor()
+			return
+		}
+	case ActionRestart:
+		restartGame(b.handler)
+		return
+	case ActionTitle:
+		LoadSetupInto(fyne.CurrentApp().Driver().AllWindows()[0])
+		return
+	case ActionZoomIn:
+		b.zoomBy(zoomStep)
+		b.showCursor()
+		return
+	case ActionZoomOut:
+		b.zoomBy(1 / zoomStep)
+		b.showCursor()
+		return
+	case ActionGoTo:
+		b.typing = true
+		b.typed = ""
+		b.status = "go:"
+		b.announce("Go to which cell?")
+		b.showCursor()
+		return
+	default:
+		return
+	}
+	b.showCursor()
+	b.announce(describeCell(b.handler, b.cursorRow, b.cursorCol))
+}
+
+// Collects a coordinate after the go-to key, enter jumps to it and escape cancels
+func (b *BoardWidget) typeCoordinate(name fyne.KeyName) {
+	switch {
+	case name == fyne.KeyEscape:
+		b.typing = false
+		b.status = ""
+		b.Refresh()
+		return
+	case name == fyne.KeyReturn || name == fyne.KeyEnter:
+		b.typing = false
+		row, col, ok := parseCoordinate(b.typed)
+		if !ok || !isiInbounds(b.handler, row, col) {
+			b.status = "??"
+			b.Refresh()
+			b.announce(b.typed + " is not a cell on this board")
+			return
+		}
+		b.cursorRow, b.cursorCol = row, col
+		b.status = ""
+		b.showCursor()
+		b.announce(describeCell(b.handler, row, col))
+		return
+	case name == fyne.KeyBackspace:
+		if len(b.typed) > 0 {
+			b.typed = b.typed[:len(b.typed)-1]
+		}
+	case len(name) == 1 && strings.ContainsAny(string(name), "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"):
+		b.typed += strings.ToLower(string(name))
+	}
+	b.status = b.typed + "_"
+	b.Refresh()
+}
+
+// Moves the cursor, stopping at the board edges (or going round to the other side on a wrap-around board). On the
+// infinite board the window scrolls instead when the cursor would go past its edge
+func (b *BoardWidget) move(dr int, dc int) {
+	rows, cols := len(b.handler.board), len(b.handler.board[0])
+	if b.handler.infinite != nil {
+		row, col := b.cursorRow+dr, b.cursorCol+dc
+		b.handler.scrollView(row-min(max(row, 0), rows-1), col-min(max(col, 0), cols-1))
+	}
+	if b.handler.gridName() == config.GridTorus {
+		b.cursorRow = (b.cursorRow + dr + rows) % rows
+		b.cursorCol = (b.cursorCol + dc + cols) % cols
+		return
+	}
+	b.cursorRow = min(max(b.cursorRow+dr, 0), rows-1)
+	b.cursorCol = min(max(b.cursorCol+dc, 0), cols-1)
+}
+
+// Shows the cursor outline on the cursor cell and scrolls it into view
+func (b *BoardWidget) showCursor() {
+	b.cursorShown = true
+	b.Refresh()
+	b.scrollTo(b.cursorRow, b.cursorCol)
+}
@@ -0,0 +1,261 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file turns the game flags from the command line (--mode, --ai, --size, --mines, --seed, --grid, --cell-mines,
+--lives, --limit, --load) into a game so main.go can skip the title and setup screens and go straight into playing, which is handy for
+demos and testing. Anything not given on the command line comes from the settings file. --load takes our save files, a RAWVF file
+(rawvf.go) or a board layout (layout.go), the last two are played from the start
+
+Functions:
+- Wanted: Whether any game flag was given
+
+- NewGame: Checks the flags and creates the game they describe
+
+- loadBoardFile: Reads the file given to --load (a save file, a RAWVF file or a board layout)
+
+- parseSize: Reads a board size such as "16x16" (width x height)
+
+- handlerMode: Gives the mode/option names of a game handler (the reverse of applyMode)
+
+Inputs:
+- Command line flag values
+
+Outputs:
+- Game handler ready to be played, or an error explaining which flag is wrong
+*/
+
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"minesweeper/config"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LaunchOptions are the game flags from the command line, zero values mean the flag was not given
+type LaunchOptions struct {
+	Mode      string // "single", "ai" (1v1 against the AI), "solver", "timed" (Time Attack), "moves" (Move Budget), "infinite" or "hotseat"
+	AI        string // "easy", "medium" or "hard"
+	Size      string // "WIDTHxHEIGHT", e.g. "16x16" or "30x16"
+	Mines     int
+	Seed      int64
+	Seeded    bool   // Whether --seed was given (0 is a valid seed)
+	Grid      string // One of config.Grids, e.g. "square", "hex" or "knight"
+	CellMines int    // Most mines a cell can hold (1 to config.MaxCellMines)
+	Lives     int    // Lives the game starts with (1 to config.MaxLives)
+	Limit     int    // Seconds for --mode timed, moves for --mode moves
+	Load      string // Save file to continue (see savegame.go), or a RAWVF file/board layout to play
+}
+
+// Tells whether any game flag was given, if not the title screen is shown as usual
+// Inputs: None
+// Outputs: Bool
+func (o LaunchOptions) Wanted() bool {
+	return o.Mode != "" || o.AI != "" || o.Size != "" || o.Mines != 0 || o.Seeded || o.Grid != "" || o.CellMines != 0 || o.Lives != 0 || o.Limit != 0 || o.Load != ""
+}
+
+// Checks the flags and creates the game they describe
+// Inputs: None
+// Outputs: The game handler with its mode applied, or an error naming the flag that is wrong
+func (o LaunchOptions) NewGame() (Gamehandler, error) {
+	// Mode and AI difficulty, --ai on its own means a game against that AI
+	mode := strings.ToLower(o.Mode)
+	if mode == "" && o.AI != "" {
+		mode = "ai"
+	}
+	option := "Play"
+	if mode == "ai" || mode == "solver" {
+		option = "Easy"
+		if o.AI != "" {
+			option = strings.ToUpper(o.AI[:1]) + strings.ToLower(o.AI[1:])
+		}
+		if option != "Easy" && option != "Medium" && option != "Hard" {
+			return Gamehandler{}, fmt.Errorf("--ai must be easy, medium or hard, not %q", o.AI)
+		}
+	}
+	var modeName string
+	switch mode {
+	case "", "single":
+		modeName = "Single"
+		if o.AI != "" {
+			return Gamehandler{}, fmt.Errorf("--ai needs --mode ai or --mode solver")
+		}
+	case "ai":
+		modeName = "AI"
+	case "solver":
+		modeName = "Solve"
+	case "timed":
+		modeName, option = "Timed", strconv.Itoa(config.TimeLimits[1])
+	case "moves":
+		modeName, option = "Moves", strconv.Itoa(config.MoveLimits[1])
+	case "infinite":
+		modeName = "Infinite"
+	case "hotseat":
+		modeName, option = "Hotseat", hotseatOption([2]string(config.Current.HotseatNames))
+	default:
+		return Gamehandler{}, fmt.Errorf("--mode must be single, ai, solver, timed, moves, infinite or hotseat, not %q", o.Mode)
+	}
+	if o.Limit != 0 {
+		switch {
+		case modeName == "Timed" && (o.Limit < 1 || o.Limit > config.MaxTimeLimit):
+			return Gamehandler{}, fmt.Errorf("--limit must be between 1 and %d seconds, not %d", config.MaxTimeLimit, o.Limit)
+		case modeName == "Moves" && (o.Limit < 1 || o.Limit > config.MaxMoveLimit):
+			return Gamehandler{}, fmt.Errorf("--limit must be between 1 and %d moves, not %d", config.MaxMoveLimit, o.Limit)
+		case modeName != "Timed" && modeName != "Moves":
+			return Gamehandler{}, fmt.Errorf("--limit needs --mode timed or --mode moves")
+		}
+		option = strconv.Itoa(o.Limit)
+	}
+
+	// The infinite board has no size or mine count, only the seed of its chunks can be picked
+	if modeName == "Infinite" {
+		if o.Load != "" || o.Size != "" || o.Mines != 0 || o.Grid != "" || o.CellMines != 0 || o.Lives != 0 {
+			return Gamehandler{}, fmt.Errorf("--mode infinite can only be used with --seed")
+		}
+		seed := time.Now().UnixNano()
+		if o.Seeded {
+			seed = o.Seed
+		}
+		return newInfiniteHandler(seed), nil
+	}
+
+	if o.Load != "" {
+		if o.Size != "" || o.Mines != 0 || o.Seeded || o.Grid != "" || o.CellMines != 0 || o.Lives != 0 {
+			return Gamehandler{}, fmt.Errorf("--load can't be used with --size, --mines, --seed, --grid, --cell-mines or --lives (they come from the save file)")
+		}
+		h, err := loadBoardFile(o.Load)
+		if err != nil {
+			return Gamehandler{}, err
+		}
+		applyMode(&h, modeName, option)
+		return h, nil
+	}
+
+	grid := config.Current.Grid
+	if o.Grid != "" {
+		grid = strings.ToLower(o.Grid)
+		if !slices.Contains(config.Grids, grid) {
+			return Gamehandler{}, fmt.Errorf("--grid must be one of %s, not %q", strings.Join(config.Grids, ", "), o.Grid)
+		}
+	}
+
+	cellMines := config.Current.CellMines
+	if o.CellMines != 0 {
+		if o.CellMines < 1 || o.CellMines > config.MaxCellMines {
+			return Gamehandler{}, fmt.Errorf("--cell-mines must be between 1 and %d, not %d", config.MaxCellMines, o.CellMines)
+		}
+		cellMines = o.CellMines
+	}
+
+	lives := config.Current.Lives
+	if o.Lives != 0 {
+		if o.Lives < 1 || o.Lives > config.MaxLives {
+			return Gamehandler{}, fmt.Errorf("--lives must be between 1 and %d, not %d", config.MaxLives, o.Lives)
+		}
+		lives = o.Lives
+	}
+
+	rows, cols := config.Current.Rows, config.Current.Cols
+	if o.Size != "" {
+		var err error
+		rows, cols, err = parseSize(o.Size)
+		if err != nil {
+			return Gamehandler{}, err
+		}
+	}
+
+	lo, hi := config.MineLimits(rows, cols)
+	mines := min(max(config.Current.Mines, lo), hi)
+	if o.Mines != 0 {
+		if o.Mines < lo || o.Mines > hi {
+			return Gamehandler{}, fmt.Errorf("--mines must be between %d and %d for a %dx%d board", lo, hi, cols, rows)
+		}
+		mines = o.Mines
+	}
+
+	seed := time.Now().UnixNano()
+	if o.Seeded {
+		seed = o.Seed
+	}
+
+	h := NewSizedGameHandler(rows, cols, mines, seed)
+	h.setCellMines(cellMines)
+	h.setLives(lives)
+	h.setTopology(topologyNamed(grid))
+	applyMode(&h, modeName, option)
+	return h, nil
+}
+
+// Reads a file with a game in it, a .rawvf file or a board layout gives a new game on that board and a save file (JSON)
+// continues the saved game
+// Inputs: File path
+// Outputs: gameHandler object, or an error saying what is wrong with the file
+func loadBoardFile(path string) (Gamehandler, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Gamehandler{}, err
+	}
+	switch {
+	case strings.EqualFold(filepath.Ext(path), ".rawvf"):
+		rep, err := ImportRAWVF(bytes.NewReader(data))
+		if err == nil {
+			var h Gamehandler
+			h, err = rep.playHandler()
+			if err == nil {
+				return h, nil
+			}
+		}
+		return Gamehandler{}, fmt.Errorf("%s: %w", path, err)
+	case strings.HasPrefix(strings.TrimSpace(string(data)), "{"):
+		return LoadGame(path)
+	}
+	return LoadLayout(path)
+}
+
+// Reads a board size written as WIDTHxHEIGHT
+// Inputs: Text such as "16x16" or "30x16"
+// Outputs: rows and cols, or an error if the text is not a size or the size is outside the allowed range
+func parseSize(text string) (int, int, error) {
+	width, height, ok := strings.Cut(strings.ToLower(text), "x")
+	cols, err1 := strconv.Atoi(strings.TrimSpace(width))
+	rows, err2 := strconv.Atoi(strings.TrimSpace(height))
+	if !ok || err1 != nil || err2 != nil {
+		return 0, 0, fmt.Errorf("--size must look like 16x16 (width x height), not %q", text)
+	}
+	if rows < config.MinBoardSize || rows > config.MaxBoardSize || cols < config.MinBoardSize || cols > config.MaxBoardSize {
+		return 0, 0, fmt.Errorf("--size width and height must be between %d and %d", config.MinBoardSize, config.MaxBoardSize)
+	}
+	return rows, cols, nil
+}
+
+// Gives the mode and option names of a game handler, the same names the setup screens use
+// Inputs: gameHandler object
+// Outputs: mode ("Single", "AI", "Solve", "Timed", "Moves", "Infinite" or "Hotseat") and option (AI difficulty, the
+// time or move limit, the hot-seat players, or "Play" for single player and infinite)
+func handlerMode(h *Gamehandler) (string, string) {
+	switch {
+	case h.aiEnabled:
+		return "AI", h.aiDifficulty
+	case h.aiSolver:
+		return "Solve", h.aiDifficulty
+	case h.timeLimit > 0:
+		return "Timed", strconv.Itoa(h.timeLimit)
+	case h.moveLimit > 0:
+		return "Moves", strconv.Itoa(h.moveLimit)
+	case h.infinite != nil:
+		return "Infinite", "Play"
+	case h.hotseat != nil:
+		return "Hotseat", hotseatOption(h.hotseat.names)
+	}
+	return "Single", "Play"
+}
@@ -0,0 +1,193 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file reads and writes board layouts as plain text so boards can be designed by hand (e.g. for training a
+pattern) and played exactly as written, and any board being played can be exported the same way. A layout is either
+a grid with one line per row, '*' for a mine and '.' for no mine:
+	..*..
+	.....
+	*...*
+or a list that starts with the board size (width x height) followed by the mine coordinates, the same names as the
+board headers, separated by spaces, commas or new lines:
+	5x3
+	c1 a3 e3
+Blank lines and lines starting with # are ignored in both. Games made from a layout are on the square grid, never move
+the mines, the first click protection is off, and Restart plays the same layout again
+
+Functions:
+- ParseLayout: Reads layout text (grid or list) into the mine rows used by mineLayout/setMineLayout
+
+- NewLayoutGameHandler: Creates a game on a layout instead of placing the mines at random
+
+- FormatLayout: Writes the mines of a board as a grid or a list
+
+- LoadLayout/SaveLayout: Read a layout file into a game, or write a game's board to one
+
+- parseLayoutSize: Reads the size line of a list
+
+Inputs:
+- Layout text / game handler
+
+Outputs:
+- Game handler on that layout / layout text
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Reads a layout written as a grid or as a size plus a list of mine coordinates (see the top of the file)
+// Inputs: Layout text
+// Outputs: One string per row, '*' for a mine and '.' for no mine, or an error naming the line that is wrong
+func ParseLayout(text string) ([]string, error) {
+	lines := []string{}
+	numbers := []int{} // Line number of each kept line, for the errors
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+		numbers = append(numbers, i+1)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("the layout is empty")
+	}
+
+	// A list starts with the size
+	if rows, cols, ok := parseLayoutSize(lines[0]); ok {
+		grid := make([][]byte, rows)
+		for r := range grid {
+			grid[r] = []byte(strings.Repeat(".", cols))
+		}
+		for i, line := range lines[1:] {
+			for _, name := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+				row, col, ok := parseCoordinate(name)
+				if !ok || row >= rows || col >= cols {
+					return nil, fmt.Errorf("line %d: %q is not a cell on a %dx%d board", numbers[i+1], name, cols, rows)
+				}
+				grid[row][col] = '*'
+			}
+		}
+		layout := make([]string, rows)
+		for r := range grid {
+			layout[r] = string(grid[r])
+		}
+		return layout, nil
+	}
+
+	// Otherwise every line is a row of the grid
+	for i, line := range lines {
+		if strings.Trim(line, "*.") != "" {
+			return nil, fmt.Errorf("line %d: a grid row can only have '*' (mine) and '.' (no mine)", numbers[i])
+		}
+		if len(line) != len(lines[0]) {
+			return nil, fmt.Errorf("line %d: expected %d cells like the first row, found %d", numbers[i], len(lines[0]), len(line))
+		}
+	}
+	return lines, nil
+}
+
+// Reads the size line at the top of a list, written as WIDTHxHEIGHT like --size
+// Inputs: The line
+// Outputs: rows and cols, and whether the line was a size within the allowed board sizes
+func parseLayoutSize(line string) (int, int, bool) {
+	width, height, ok := strings.Cut(strings.ToLower(line), "x")
+	cols, err1 := strconv.Atoi(strings.TrimSpace(width))
+	rows, err2 := strconv.Atoi(strings.TrimSpace(height))
+	if !ok || err1 != nil || err2 != nil || rows < 1 || cols < 1 || rows > config.MaxBoardSize || cols > config.MaxBoardSize {
+		return 0, 0, false
+	}
+	return rows, cols, true
+}
+
+// Creates a game with the mines exactly where a layout puts them, nothing is placed at random and the first click
+// won't move a mine (it still records the layout for the replay)
+// Inputs: One string per row, '*' for a mine and '.' for no mine (or a digit for a cell with several, see mineLayout)
+// Outputs: gameHandler object (single player), or an error if the layout has no rows or no safe cell
+func NewLayoutGameHandler(layout []string) (Gamehandler, error) {
+	if len(layout) == 0 || len(layout[0]) == 0 {
+		return Gamehandler{}, fmt.Errorf("the layout is empty")
+	}
+	if len(layout) > config.MaxBoardSize || len(layout[0]) > config.MaxBoardSize {
+		return Gamehandler{}, fmt.Errorf("the layout is bigger than the largest board (%dx%d)", config.MaxBoardSize, config.MaxBoardSize)
+	}
+	h := NewSizedGameHandler(len(layout), len(layout[0]), 0, 0)
+	if err := setMineLayout(&h, layout); err != nil {
+		return Gamehandler{}, err
+	}
+	if !strings.Contains(strings.Join(layout, ""), ".") {
+		return Gamehandler{}, fmt.Errorf("the layout has no cell without a mine")
+	}
+	h.firstClickPolicy = config.FirstClickNone
+	h.layout = slices.Clone(layout)
+	return h, nil
+}
+
+// Writes the mines of a board as layout text, the board the first click moved them to if it was already made
+// Inputs: gameHandler object and whether to write a list instead of a grid
+// Outputs: Layout text that ParseLayout reads back
+func FormatLayout(handler *Gamehandler, list bool) string {
+	out := strings.Builder{}
+	if !list {
+		for _, row := range mineLayout(handler) {
+			out.WriteString(row + "\n")
+		}
+		return out.String()
+	}
+	fmt.Fprintf(&out, "%dx%d\n", handler.cols, handler.rows)
+	names := []string{}
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			if handler.board[r][c].isBomb() {
+				names = append(names, cellName(r, c))
+			}
+		}
+	}
+	out.WriteString(strings.Join(names, " ") + "\n")
+	return out.String()
+}
+
+// Reads a layout file into a new game
+// Inputs: The file path
+// Outputs: gameHandler object on that layout, or an error saying what is wrong with the file
+func LoadLayout(path string) (Gamehandler, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Gamehandler{}, err
+	}
+	layout, err := ParseLayout(string(data))
+	if err != nil {
+		return Gamehandler{}, fmt.Errorf("%s: %w", path, err)
+	}
+	h, err := NewLayoutGameHandler(layout)
+	if err != nil {
+		return Gamehandler{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Writes the mines of a game to a layout file, layouts are always played on the square grid with one mine per cell so
+// other boards can't be written
+// Inputs: gameHandler object, the file path and whether to write a list instead of a grid
+// Outputs: Error if the board is not on the square grid, has cells with several mines or the file could not be written
+func SaveLayout(handler *Gamehandler, path string, list bool) error {
+	if handler.gridName() != config.GridSquare {
+		return fmt.Errorf("only boards on the square grid can be exported as a layout")
+	}
+	if handler.cellMines > 1 {
+		return fmt.Errorf("only boards with one mine per cell can be exported as a layout")
+	}
+	return os.WriteFile(path, []byte(FormatLayout(handler, list)), 0o644)
+}
@@ -0,0 +1,61 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- Tests for reading board layouts (layout.go) in grid and list form
+
+Functions:
+- TestParseLayout: Layout texts and the mine rows or error they give
+
+Inputs:
+- None
+
+Outputs:
+- Test results
+*/
+
+package components
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+		err  string // Part of the error message, empty if the layout is fine
+	}{
+		{"grid", "..*..\n.....\n*...*\n", []string{"..*..", ".....", "*...*"}, ""},
+		{"grid with comments", "# a pattern\n\n*.\n.*\n", []string{"*.", ".*"}, ""},
+		{"list", "5x3\nc1 a3 e3\n", []string{"..*..", ".....", "*...*"}, ""},
+		{"list with commas", "3x2\na1, c2\n", []string{"*..", "..*"}, ""},
+		{"uneven grid", "*..\n..\n", nil, "line 2"},
+		{"unknown letter", "*.x\n...\n", nil, "line 1"},
+		{"cell off the board", "3x2\nd1\n", nil, "d1"},
+		{"empty", "# nothing here\n", nil, "empty"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseLayout(test.text)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("ParseLayout(%q) error = %v, want one mentioning %q", test.text, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLayout(%q) error = %v", test.text, err)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("ParseLayout(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
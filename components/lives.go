@@ -0,0 +1,98 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the lives option. A game can start with up to config.MaxLives lives (picked on the mine setup screen or
+with --lives, 1 is the classic game where the first mine ends it). Hitting a mine takes a life: the mine stays
+uncovered and is drawn as exploded, its number still counts it and chording and the AIs treat it like a flagged mine,
+and play goes on. The game is lost when the last life is gone and won the same way as always. Lives games are counted
+in the stats on their own (stats.go) so they don't mix with the wins of normal games
+
+Functions:
+- setLives: Sets how many lives a new game has
+
+- hitMine: Takes a life for a mine that was hit
+
+- livesFromBoard: Works out the lives left of a board that was read back from a file
+
+- livesText: The lives left for the headers and status lines
+
+- savedLives: The lives written to save and replay files
+
+Inputs:
+- Game handler
+
+Outputs:
+- Lives left, whether the game goes on
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+)
+
+// Sets how many lives a game starts with, only for games that haven't been clicked yet
+// Inputs: gameHandler object and the lives (1 to config.MaxLives)
+// Outputs: None, changes the handler
+func (handler *Gamehandler) setLives(n int) {
+	handler.startLives = min(max(n, 1), config.MaxLives)
+	handler.lives = handler.startLives
+}
+
+// Takes a life for a mine that was hit, in a lives game the mine is uncovered and marked as exploded. A cell with
+// several mines (multimine.go) still takes one life
+// Inputs: gameHandler object and row/col of the mine
+// Outputs: True if the game goes on, false if that was the last life (or a classic game) and the game is lost
+func (handler *Gamehandler) hitMine(row, col int) bool {
+	handler.lives = max(handler.lives-1, 0)
+	if handler.startLives <= 1 {
+		return false
+	}
+	sq := &handler.board[row][col]
+	sq.state, sq.exploded = Uncovered, true
+	return handler.lives > 0
+}
+
+// Works out the lives left on a board read back from a save file or a replay: every mine showing took a life, in a
+// lives game those are marked as exploded. The game is over once no lives are left
+// Inputs: gameHandler object with the board and the lives it started with
+// Outputs: None, changes the handler
+func (handler *Gamehandler) livesFromBoard() {
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			sq := &handler.board[r][c]
+			if sq.isBomb() && sq.state == Uncovered {
+				handler.lives = max(handler.lives-1, 0)
+				sq.exploded = handler.startLives > 1
+			}
+		}
+	}
+	if handler.lives == 0 {
+		handler.gameOver = true
+	}
+}
+
+// Gives the lives left for the headers and status lines
+// Inputs: gameHandler object
+// Outputs: Text such as "Lives: 2 of 3", empty for a classic game
+func livesText(handler *Gamehandler) string {
+	if handler.startLives <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("Lives: %d of %d", handler.lives, handler.startLives)
+}
+
+// Gives the lives to write to a save or replay file, 0 for the classic game so the field is left out
+// Inputs: gameHandler object
+// Outputs: Lives the game started with, or 0
+func savedLives(handler *Gamehandler) int {
+	if handler.startLives > 1 {
+		return handler.startLives
+	}
+	return 0
+}
@@ -12,7 +12,6 @@ package components
 //Import Library
 import (
 	"math/rand"
-	"minesweeper/config"
 	"time"
 )
 
@@ -28,6 +27,8 @@ func MediumAIMove(handler *Gamehandler) bool {
 	if handler == nil || handler.gameOver {
 		return false
 	}
+	handler.aiMoving = true // Moves made from here on are recorded as the AI's (replay.go)
+	defer func() { handler.aiMoving = false }()
 
 	//Local Variables
 	var rng *rand.Rand  //random
@@ -44,13 +45,13 @@ func MediumAIMove(handler *Gamehandler) bool {
 	}
 
 	//Collect All Covered Cells
-	covered_cells := make([]cell, 0, config.BoardSize*config.BoardSize)
-	number_cells := make([]cell, 0, config.BoardSize*config.BoardSize)
+	covered_cells := make([]cell, 0, handler.rows*handler.cols)
+	number_cells := make([]cell, 0, handler.rows*handler.cols)
 
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
 			sq := &handler.board[r][c]
-			if sq.state == Covered {
+			if sq.isCovered() {
 				covered_cells = append(covered_cells, cell{r, c})
 			} else if sq.state == Uncovered && sq.numValue != 0 {
 				number_cells = append(number_cells, cell{r, c})
@@ -66,8 +67,10 @@ func MediumAIMove(handler *Gamehandler) bool {
 		selNumCell := number_cells[rng.Intn(len(number_cells))]
 		posCell = neighbor_tracker(handler, selNumCell)
 
-		//AI Click Mode or Flag Mode
-		if handler.board[selNumCell.r][selNumCell.c].numValue == len(posCell) {
+		//AI Click Mode or Flag Mode (every covered cell holding as many mines as a cell can), the flags and exploded
+		//mines around the number are already accounted for
+		mines_left := handler.board[selNumCell.r][selNumCell.c].numValue - handler.flagsAround(selNumCell.r, selNumCell.c)
+		if mines_left == len(posCell)*handler.cellMines {
 			flag_mode = true
 		} else {
 			flag_mode = false
@@ -91,7 +94,7 @@ func MediumAIMove(handler *Gamehandler) bool {
 		handler.board[move.r][move.c].markedByAI = true
 
 		if flag_mode {
-			handler.ToggleFlag(move.r, move.c)
+			handler.Flag(move.r, move.c)
 			return true
 
 		} else {
@@ -110,60 +113,18 @@ func MediumAIMove(handler *Gamehandler) bool {
 }
 
 //Neighbor Tracker Function || nc = number cell
-/* This function is used to check the 8 surrounding cells of a numbered cell
+/* This function is used to check the cells around a numbered cell (the 8 surrounding cells on the square grid, which
+ * ones depends on the board's topology, see topology.go)
  * Input: a single number cell
  * Output: a slice of covered tiles
  */
 func neighbor_tracker(handler *Gamehandler, nc cell) []cell {
 	//Local Variable
-	next_to_number_cells := make([]cell, 0, config.BoardSize*config.BoardSize)
+	next_to_number_cells := make([]cell, 0, handler.rows*handler.cols)
 
-	//Top-Left Cell
-	if nc.r-1 >= 0 && nc.r-1 < config.BoardSize && nc.c-1 >= 0 && nc.c-1 < config.BoardSize {
-		if handler.board[nc.r-1][nc.c-1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r - 1, nc.c - 1})
-		}
-	}
-	//Top-Mid Cell
-	if nc.r-1 >= 0 && nc.r-1 < config.BoardSize && nc.c >= 0 && nc.c < config.BoardSize {
-		if handler.board[nc.r-1][nc.c].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r - 1, nc.c})
-		}
-	}
-	//Top-Right Cell
-	if nc.r-1 >= 0 && nc.r-1 < config.BoardSize && nc.c+1 >= 0 && nc.c+1 < config.BoardSize {
-		if handler.board[nc.r-1][nc.c+1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r - 1, nc.c + 1})
-		}
-	}
-	//Mid-Left Cell
-	if nc.r >= 0 && nc.r < config.BoardSize && nc.c-1 >= 0 && nc.c-1 < config.BoardSize {
-		if handler.board[nc.r][nc.c-1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r, nc.c - 1})
-		}
-	}
-	//Mid-Right Cell
-	if nc.r >= 0 && nc.r < config.BoardSize && nc.c+1 >= 0 && nc.c+1 < config.BoardSize {
-		if handler.board[nc.r][nc.c+1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r, nc.c + 1})
-		}
-	}
-	//Bot-Left Cell
-	if nc.r+1 >= 0 && nc.r+1 < config.BoardSize && nc.c-1 >= 0 && nc.c-1 < config.BoardSize {
-		if handler.board[nc.r+1][nc.c-1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r + 1, nc.c - 1})
-		}
-	}
-	//Bot-Mid Cell
-	if nc.r+1 >= 0 && nc.r+1 < config.BoardSize && nc.c >= 0 && nc.c < config.BoardSize {
-		if handler.board[nc.r+1][nc.c].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r + 1, nc.c})
-		}
-	}
-	//Bot-Right Cell
-	if nc.r+1 >= 0 && nc.r+1 < config.BoardSize && nc.c+1 >= 0 && nc.c+1 < config.BoardSize {
-		if handler.board[nc.r+1][nc.c+1].state == Covered {
-			next_to_number_cells = append(next_to_number_cells, cell{nc.r + 1, nc.c + 1})
+	for _, n := range handler.neighbors(nc.r, nc.c) {
+		if handler.board[n.r][n.c].isCovered() {
+			next_to_number_cells = append(next_to_number_cells, cell{n.r, n.c})
 		}
 	}
 
@@ -0,0 +1,80 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the multi-mine variant, where a cell can hold up to N mines (N is picked on the mine setup screen, the
+classic game is N = 1). A number is the total of the mines in its neighbors, so a 3 can be one cell with three mines
+in it. To mark a cell the player puts as many flags on it as it has mines: right click adds a flag until the cell has
+N, the next right click takes them all off again. Chording and the AIs count flags, not flagged cells, and the game is
+won the same way as always, by revealing every cell without a mine. Daily challenges, puzzles and board layouts are
+always classic boards
+
+Functions:
+- setCellMines: Changes how many mines a cell of a new game can hold and places the mines again
+
+- savedCellMines: The mines per cell written to save and replay files
+
+- flagRoom: How many more flags a cell can take
+
+Inputs:
+- Game handler
+
+Outputs:
+- The game with the new rules
+*/
+
+package components
+
+import (
+	"math/rand"
+	"minesweeper/config"
+)
+
+// Changes how many mines a cell can hold and places the mines again from the game's seed, so a seed gives the same
+// board every time for each number of mines per cell (and the classic board for 1). Only for games that haven't been
+// clicked yet
+// Inputs: gameHandler object and the most mines a cell can hold
+// Outputs: None, changes the board
+func (handler *Gamehandler) setCellMines(n int) {
+	n = min(max(n, 1), config.MaxCellMines)
+	if n == handler.cellMines {
+		return
+	}
+	handler.cellMines = n
+	for r := range handler.board {
+		for c := range handler.board[r] {
+			handler.board[r][c].bombs = 0
+		}
+	}
+	handler.rng = rand.New(rand.NewSource(handler.seed))
+	handler.placeMines(handler.totalMines)
+	handler.AddNumbers()
+}
+
+// Gives the mines per cell to write to a save or replay file, 0 for the classic game so the field is left out
+// Inputs: gameHandler object
+// Outputs: Mines per cell, or 0
+func savedCellMines(handler *Gamehandler) int {
+	if handler.cellMines > 1 {
+		return handler.cellMines
+	}
+	return 0
+}
+
+// Gives how many more flags a cell can take: all of them on a covered cell, what is left on a flagged one and none on
+// an uncovered one
+// Inputs: gameHandler object and row/col of the cell
+// Outputs: Number of flags
+func (handler *Gamehandler) flagRoom(row, col int) int {
+	sq := handler.board[row][col]
+	switch {
+	case sq.isCovered():
+		return handler.cellMines
+	case sq.state == Flagged:
+		return handler.cellMines - sq.flags
+	}
+	return 0
+}
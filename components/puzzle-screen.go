@@ -0,0 +1,119 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the puzzle list, opened with Puzzles on the game mode screen. It shows the puzzles of a pack with the
+progress saved for each one (solved or not and the fewest mistakes), the first unsolved puzzle is highlighted. Other
+packs can be loaded from a file (see puzzle.go for the format)
+
+Functions:
+- showPuzzles: Shows the puzzles of a pack, tapping one starts it
+
+- loadPuzzlePack: Asks for a pack file and shows its puzzles
+
+- puzzleStatus: Describes the saved progress of one puzzle
+
+Inputs:
+- Puzzle pack and the saved progress
+
+Outputs:
+- The puzzle list, the chosen puzzle's game
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Shows the puzzles of a pack with their progress, tapping one starts it
+// Inputs: the fyne window and the pack
+// Outputs: None, replaces the window content
+func showPuzzles(win fyne.Window, pack *PuzzlePack) {
+	progress, err := LoadPuzzleProgress()
+	if err != nil {
+		fmt.Println("could not read the puzzle progress:", err)
+	}
+
+	list := container.NewVBox()
+	solved := 0
+	next := true // Highlight the first unsolved puzzle
+	for i, p := range pack.Puzzles {
+		result := progress[puzzleKey(pack, i)]
+		if result.Solved {
+			solved++
+		}
+		button := widget.NewButton(fmt.Sprintf("%s (%dx%d): %s", p.Name, len(p.Board[0]), len(p.Board), puzzleStatus(result)), func() {
+			h, err := NewPuzzleHandler(pack, i)
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			ShowGame(win, &h)
+		})
+		if next && !result.Solved {
+			button.Importance = widget.HighImportance
+			next = false
+		}
+		list.Add(button)
+	}
+
+	title := widget.NewLabel(fmt.Sprintf("%s: %d of %d solved", pack.Name, solved, len(pack.Puzzles)))
+	help := widget.NewLabel("Find every safe cell without guessing, moves the numbers don't prove are refused.")
+	help.Wrapping = fyne.TextWrapWord
+	loadButton := widget.NewButton("Load Pack", func() {
+		loadPuzzlePack(win)
+	})
+	backButton := widget.NewButton("Back", func() {
+		gameSelect(win)
+	})
+
+	win.SetContent(container.NewPadded(container.NewBorder(container.NewVBox(title, help),
+		container.NewVBox(loadButton, backButton), nil, nil, container.NewVScroll(list))))
+}
+
+// Asks for a puzzle pack file and shows its puzzles
+// Inputs: the fyne window itself
+// Outputs: None, shows an error dialog if the pack could not be read
+func loadPuzzlePack(win fyne.Window) {
+	dialog.ShowFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if file == nil {
+			return // Cancelled
+		}
+		path := file.URI().Path()
+		file.Close()
+		pack, err := LoadPuzzlePack(path)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		showPuzzles(win, &pack)
+	}, win)
+}
+
+// Describes the saved progress of a puzzle, e.g. "solved, 2 mistakes"
+// Inputs: The saved result (zero if it was never solved)
+// Outputs: Description
+func puzzleStatus(result PuzzleResult) string {
+	switch {
+	case !result.Solved:
+		return "not solved"
+	case result.Mistakes == 0:
+		return "solved, no mistakes"
+	case result.Mistakes == 1:
+		return "solved, 1 mistake"
+	}
+	return fmt.Sprintf("solved, %d mistakes", result.Mistakes)
+}
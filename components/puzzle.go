@@ -0,0 +1,566 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the puzzle mode. A puzzle is a board that starts partly revealed and can be solved to the end without
+guessing, the player has to find the safe cells and mines the numbers prove. Every move is checked against the puzzle
+solver first: revealing a cell or flagging a mine the numbers don't prove yet is refused and counted as a mistake, so
+a puzzle can't be won by luck. Puzzles come in packs, a text file with a "pack:" line giving its name and a "puzzle:"
+line before each board. The boards use the save file letters (savegame.go), '.' covered, '*' mine, 'o' revealed and
+'F' a mine that starts flagged:
+	pack: Warm-up
+	puzzle: Corner
+	oo.
+	o**
+Every puzzle of a pack is checked with the solver when the pack is read. Which puzzles were solved, and with how few
+mistakes, is saved in puzzles.json next to the settings file
+
+Functions:
+- ParsePuzzlePack/LoadPuzzlePack: Read a puzzle pack from text or a file, checking every puzzle
+
+- DefaultPuzzlePack: The puzzles that come with the game
+
+- NewPuzzleHandler: Creates the game for one puzzle of a pack
+
+- certainCells: The puzzle solver, finds every covered cell the numbers and the mine count prove safe or a mine
+
+- solveComponent: Tries every way of putting mines on one group of cells that share numbers
+
+- checkSolvable: Plays a puzzle with the solver to make sure it never needs a guess
+
+- puzzleAllows: Checks a player move against the solver, refusing guesses
+
+- LoadPuzzleProgress/recordPuzzleSolved: Read the saved progress and save a solved puzzle
+
+Inputs:
+- Puzzle pack text/files and the player's moves
+
+Outputs:
+- Puzzle games, refused moves with the reason, saved progress
+*/
+
+package components
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"minesweeper/config"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Largest group of cells sharing numbers the solver tries every mine placement for (2^n placements at most), bigger
+// groups are left unsolved so a bad pack can't hang the game
+const maxPuzzleGroup = 24
+
+// Puzzle is one board of a pack, the rows use the save file letters
+type Puzzle struct {
+	Name  string
+	Board []string
+}
+
+// PuzzlePack is a named list of puzzles, what a pack file holds
+type PuzzlePack struct {
+	Name    string
+	Puzzles []Puzzle
+}
+
+// PuzzleResult is the saved progress of one puzzle
+type PuzzleResult struct {
+	Solved   bool `json:"solved"`
+	Mistakes int  `json:"mistakes"` // Fewest mistakes it was solved with
+}
+
+// What a puzzle game keeps on top of the game handler
+type puzzleState struct {
+	pack     *PuzzlePack
+	index    int    // Which puzzle of the pack this is
+	mistakes int    // Moves refused so far
+	message  string // Why the last move was refused, shown under the board until the next announcement
+}
+
+// The puzzles that come with the game, from small patterns to whole boards
+const defaultPuzzles = `pack: Classic Patterns
+
+puzzle: First Steps
+..ooo
+.*ooo
+..ooo
+...*.
+*..**
+
+puzzle: Edge Walk
+*ooooo.
+oooooo*
+ooooo*.
+oooooo*
+.*oooo.
+
+puzzle: Two Columns
+..*ooo*
+*oooooo
+*oooooo
+.oooooo
+.*ooooo
+
+puzzle: The Wall
+..ooooo*.
+.*ooooo..
+..ooooo**
+.*ooooo..
+.oooooo*.
+*ooooo*..
+
+puzzle: Notch
+....ooo*
+...*oooo
+....oooo
+...*oooo
+*.*.**oo
+
+puzzle: Staircase
+.*...**
+*.*....
+oo..*.*
+oo.*...
+oo*ooo.
+oooooo*
+oooooo*
+
+puzzle: Open Left
+ooo......
+ooo**...*
+ooo......
+ooo*..**.
+oooooo*..
+oooooo..*
+
+puzzle: Deep Field
+.....*.*
+*..*....
+oo...*..
+oo*.....
+oooo...*
+oooo*...
+oooo....
+ooo**...
+ooo.....
+
+puzzle: Long Way Down
+.*...*
+ooo*..
+ooo...
+ooo*.*
+ooo...
+.**..*
+.*....
+......
+.*....
+`
+
+// Reads a puzzle pack and checks that every puzzle in it can be solved without guessing
+// Inputs: Pack text (see the top of the file)
+// Outputs: The pack, or an error naming the line or puzzle that is wrong
+func ParsePuzzlePack(text string) (PuzzlePack, error) {
+	pack := PuzzlePack{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, ":")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "pack":
+			pack.Name = strings.TrimSpace(value)
+		case "puzzle":
+			pack.Puzzles = append(pack.Puzzles, Puzzle{Name: strings.TrimSpace(value)})
+		default:
+			if len(pack.Puzzles) == 0 {
+				return PuzzlePack{}, fmt.Errorf("line %d: a board row needs a \"puzzle:\" line before it", i+1)
+			}
+			last := &pack.Puzzles[len(pack.Puzzles)-1]
+			last.Board = append(last.Board, line)
+		}
+	}
+	if pack.Name == "" {
+		return PuzzlePack{}, fmt.Errorf("the \"pack:\" line with the pack's name is missing")
+	}
+	if len(pack.Puzzles) == 0 {
+		return PuzzlePack{}, fmt.Errorf("the pack has no puzzles")
+	}
+	for i := range pack.Puzzles {
+		if _, err := NewPuzzleHandler(&pack, i); err != nil {
+			return PuzzlePack{}, err
+		}
+	}
+	return pack, nil
+}
+
+// Reads a puzzle pack file
+// Inputs: The file path
+// Outputs: The pack, or an error saying what is wrong with the file
+func LoadPuzzlePack(path string) (PuzzlePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return PuzzlePack{}, err
+	}
+	pack, err := ParsePuzzlePack(string(data))
+	if err != nil {
+		return PuzzlePack{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return pack, nil
+}
+
+// Gives the pack of puzzles that come with the game
+// Inputs: None
+// Outputs: The pack
+func DefaultPuzzlePack() PuzzlePack {
+	pack, err := ParsePuzzlePack(defaultPuzzles)
+	if err != nil {
+		panic("the built in puzzles are broken: " + err.Error())
+	}
+	return pack
+}
+
+// Creates the game for one puzzle, the mines stay where the puzzle puts them and the revealed cells start uncovered
+// Inputs: The pack and which of its puzzles to play
+// Outputs: gameHandler object with the puzzle checks turned on, or an error if the puzzle's board is wrong or it
+// can't be solved without guessing
+func NewPuzzleHandler(pack *PuzzlePack, index int) (Gamehandler, error) {
+	p := pack.Puzzles[index]
+	fail := func(format string, args ...any) (Gamehandler, error) {
+		return Gamehandler{}, fmt.Errorf("puzzle %q: %s", p.Name, fmt.Sprintf(format, args...))
+	}
+	if len(p.Board) == 0 {
+		return fail("the board is missing")
+	}
+	if len(p.Board) > config.MaxBoardSize || len(p.Board[0]) > config.MaxBoardSize {
+		return fail("the board is bigger than the largest board (%dx%d)", config.MaxBoardSize, config.MaxBoardSize)
+	}
+	for r, row := range p.Board {
+		if strings.Trim(row, ".*oF") != "" {
+			return fail("board row %d can only have '.', '*', 'o' and 'F'", r+1)
+		}
+	}
+
+	h := NewSizedGameHandler(len(p.Board), len(p.Board[0]), 0, 0)
+	if err := decodeBoard(&h, p.Board); err != nil {
+		return fail("%v", err)
+	}
+	if strings.Count(strings.Join(p.Board, ""), "o") == 0 {
+		return fail("no cell is revealed")
+	}
+	h.AddNumbers()
+	h.firstClick = false
+	h.firstClickPolicy = config.FirstClickNone
+	h.questionMarks = false
+	h.checkWin()
+	if h.gameOver {
+		return fail("every safe cell is already revealed")
+	}
+	if err := checkSolvable(&h); err != nil {
+		return fail("%v", err)
+	}
+	h.start = encodeBoard(&h)
+	h.puzzle = &puzzleState{pack: pack, index: index}
+	return h, nil
+}
+
+// Finds every covered cell whose state the visible numbers prove. Each revealed number says how many mines are among
+// its covered neighbors; the numbers that share cells are solved together by trying every mine placement that fits
+// them all, a cell that is a mine in every placement is a mine and one that is clear in every placement is safe. Flags
+// are trusted since a puzzle only lets the player place proven ones. Once all the mines are found the rest is safe,
+// and if the covered cells left are all mines they all are
+// Inputs: gameHandler object
+// Outputs: The proven safe cells and the proven mines (not including flagged ones)
+func certainCells(handler *Gamehandler) (map[hardCell]bool, map[hardCell]bool) {
+	safe := map[hardCell]bool{}
+	mines := map[hardCell]bool{}
+
+	// One rule per number with covered neighbors: exactly need of cells are mines
+	type rule struct {
+		cells []hardCell
+		need  int
+	}
+	rules := []rule{}
+	rulesOf := map[hardCell][]int{}
+	unknown, flags := []hardCell{}, 0
+	for r := 0; r < handler.rows; r++ {
+		for c := 0; c < handler.cols; c++ {
+			sq := handler.board[r][c]
+			switch {
+			case sq.state == Flagged:
+				flags++
+				continue
+			case sq.isCovered():
+				unknown = append(unknown, hardCell{r, c})
+				continue
+			case sq.isBomb():
+				continue
+			}
+			rl := rule{need: sq.numValue}
+			for _, n := range getAllNeighbors(handler, hardCell{r, c}) {
+				if handler.board[n.r][n.c].state == Flagged {
+					rl.need--
+				} else if handler.board[n.r][n.c].isCovered() {
+					rl.cells = append(rl.cells, n)
+				}
+			}
+			if len(rl.cells) > 0 {
+				for _, n := range rl.cells {
+					rulesOf[n] = append(rulesOf[n], len(rules))
+				}
+				rules = append(rules, rl)
+			}
+		}
+	}
+
+	// The mine count on its own
+	left := handler.totalMines - flags
+	if left == 0 || left == len(unknown) {
+		for _, cell := range unknown {
+			if left == 0 {
+				safe[cell] = true
+			} else {
+				mines[cell] = true
+			}
+		}
+		return safe, mines
+	}
+
+	// Split the covered cells next to numbers into groups that share numbers and solve each group
+	seen := map[hardCell]bool{}
+	for _, start := range unknown {
+		if seen[start] || len(rulesOf[start]) == 0 {
+			continue
+		}
+		group := []hardCell{start}
+		seen[start] = true
+		for i := 0; i < len(group); i++ {
+			for _, ri := range rulesOf[group[i]] {
+				for _, n := range rules[ri].cells {
+					if !seen[n] {
+						seen[n] = true
+						group = append(group, n)
+					}
+				}
+			}
+		}
+		if len(group) > maxPuzzleGroup {
+			continue
+		}
+		cellRules := make([][]int, len(group))
+		needs := map[int]int{}
+		for i, cell := range group {
+			cellRules[i] = rulesOf[cell]
+			for _, ri := range rulesOf[cell] {
+				needs[ri] = rules[ri].need
+			}
+		}
+		sizes := map[int]int{}
+		for ri := range needs {
+			sizes[ri] = len(rules[ri].cells)
+		}
+		placements, mineCount := solveComponent(cellRules, needs, sizes)
+		if placements == 0 {
+			continue // The numbers contradict each other (can't happen on a real board)
+		}
+		for i, cell := range group {
+			switch mineCount[i] {
+			case 0:
+				safe[cell] = true
+			case placements:
+				mines[cell] = true
+			}
+		}
+	}
+	return safe, mines
+}
+
+// Tries every way of putting mines on a group of cells, skipping placements as soon as a number has too many mines or
+// can't get enough any more
+// Inputs: the rules each cell is part of, the mines each rule needs and how many cells each rule has
+// Outputs: How many placements fit every rule, and for each cell in how many of them it is a mine
+func solveComponent(cellRules [][]int, needs map[int]int, sizes map[int]int) (int, []int) {
+	placed := map[int]int{} // Mines put on each rule's cells so far
+	open := map[int]int{}   // Cells of each rule not decided yet
+	for ri, size := range sizes {
+		open[ri] = size
+	}
+	mine := make([]bool, len(cellRules))
+	mineCount := make([]int, len(cellRules))
+	placements := 0
+
+	var try func(i int)
+	try = func(i int) {
+		if i == len(cellRules) {
+			placements++
+			for j, m := range mine {
+				if m {
+					mineCount[j]++
+				}
+			}
+			return
+		}
+		for _, isMine := range []bool{false, true} {
+			fits := true
+			for _, ri := range cellRules[i] {
+				open[ri]--
+				if isMine {
+					placed[ri]++
+				}
+				if placed[ri] > needs[ri] || placed[ri]+open[ri] < needs[ri] {
+					fits = false
+				}
+			}
+			if fits {
+				mine[i] = isMine
+				try(i + 1)
+			}
+			for _, ri := range cellRules[i] {
+				open[ri]++
+				if isMine {
+					placed[ri]--
+				}
+			}
+		}
+	}
+	try(0)
+	return placements, mineCount
+}
+
+// Plays a puzzle with the solver on a copy of the board, making every proven move each round, to check it never
+// gets stuck before every safe cell is revealed
+// Inputs: gameHandler object of the puzzle (not changed)
+// Outputs: Error saying how far the solver got if a guess is needed
+func checkSolvable(handler *Gamehandler) error {
+	h := *handler
+	h.board = make([][]Square, len(handler.board))
+	for r := range handler.board {
+		h.board[r] = append([]Square(nil), handler.board[r]...)
+	}
+	h.moves = nil
+	h.onChange = nil
+	for round := 1; !h.gameOver; round++ {
+		safe, mines := certainCells(&h)
+		if len(safe) == 0 && len(mines) == 0 {
+			return fmt.Errorf("it needs a guess after %d rounds of proven moves", round-1)
+		}
+		for cell := range mines {
+			h.board[cell.r][cell.c].state = Flagged
+		}
+		for cell := range safe {
+			if h.board[cell.r][cell.c].isBomb() {
+				return fmt.Errorf("the solver thinks %s is safe but it is a mine", cellName(cell.r, cell.c))
+			}
+			h.click(cell.r, cell.c)
+		}
+	}
+	if !h.win {
+		return fmt.Errorf("the solver hit a mine")
+	}
+	return nil
+}
+
+// Checks a player move in a puzzle: reveals and flags are only allowed on cells the numbers prove, anything else is a
+// guess and is refused (and counted as a mistake). Flags in a puzzle are always right so they can't be taken off.
+// Games that aren't puzzles allow everything
+// Inputs: gameHandler object, kind of move (MoveReveal or MoveFlag) and its cell
+// Outputs: Whether the move may be made, if not the reason is left in the puzzle's message
+func (handler *Gamehandler) puzzleAllows(kind string, row int, col int) bool {
+	p := handler.puzzle
+	if p == nil || handler.gameOver {
+		return true
+	}
+	name := cellName(row, col)
+	safe, mines := certainCells(handler)
+	cell := hardCell{row, col}
+	switch {
+	case kind == MoveFlag && handler.board[row][col].state == Flagged:
+		p.message = name + " is a proven mine, puzzle flags stay put"
+		return false
+	case kind == MoveReveal && safe[cell], kind == MoveFlag && mines[cell]:
+		p.message = ""
+		return true
+	case kind == MoveReveal && mines[cell]:
+		p.message = name + " is a mine, the numbers prove it"
+	case kind == MoveFlag && safe[cell]:
+		p.message = name + " is safe, the numbers prove it"
+	default:
+		p.message = name + " isn't proven yet, that would be a guess"
+	}
+	p.mistakes++
+	p.message = fmt.Sprintf("%s (mistakes: %d)", p.message, p.mistakes)
+	return false
+}
+
+// Gives the file the puzzle progress is saved in, next to the settings file
+func puzzleProgressPath() (string, error) {
+	path, err := config.SettingsPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "puzzles.json"), nil
+}
+
+// Reads the saved puzzle progress
+// Inputs: None
+// Outputs: Result of every puzzle played, keyed by "pack/puzzle" (empty if nothing was solved yet)
+func LoadPuzzleProgress() (map[string]PuzzleResult, error) {
+	progress := map[string]PuzzleResult{}
+	path, err := puzzleProgressPath()
+	if err != nil {
+		return progress, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, err
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return map[string]PuzzleResult{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return progress, nil
+}
+
+// Gives the progress key of a puzzle
+func puzzleKey(pack *PuzzlePack, index int) string {
+	return pack.Name + "/" + pack.Puzzles[index].Name
+}
+
+// Saves a solved puzzle in the progress file, keeping the fewest mistakes it was ever solved with
+// Inputs: gameHandler object of the solved puzzle
+// Outputs: Error if the progress could not be read or written
+func recordPuzzleSolved(handler *Gamehandler) error {
+	progress, err := LoadPuzzleProgress()
+	if err != nil {
+		return err
+	}
+	key := puzzleKey(handler.puzzle.pack, handler.puzzle.index)
+	result, played := progress[key]
+	if !played || !result.Solved || handler.puzzle.mistakes < result.Mistakes {
+		result = PuzzleResult{Solved: true, Mistakes: handler.puzzle.mistakes}
+	}
+	progress[key] = result
+
+	path, err := puzzleProgressPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,79 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- Tests for reading puzzle packs (puzzle.go), including the solver check that refuses puzzles needing a guess
+
+Functions:
+- TestParsePuzzlePack: Pack texts and the puzzles or error they give
+
+- TestDefaultPuzzlePack: The built in pack loads
+
+Inputs:
+- None
+
+Outputs:
+- Test results
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+// A small puzzle the solver can finish without guessing
+const testPuzzleBoard = "..ooo\n.*ooo\n..ooo\n...*.\n*..**\n"
+
+func TestParsePuzzlePack(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		puzzles []string // Names of the puzzles read
+		err     string   // Part of the error message, empty if the pack is fine
+	}{
+		{"one puzzle", "pack: Test\npuzzle: Corner\n" + testPuzzleBoard, []string{"Corner"}, ""},
+		{"two puzzles with comments", "# mine\npack: Test\n\npuzzle: A\n" + testPuzzleBoard + "\npuzzle: B\n" + testPuzzleBoard,
+			[]string{"A", "B"}, ""},
+		{"needs a guess", "pack: Test\npuzzle: Coin Flip\noo\noo\n*.\n", nil, `"Coin Flip": it needs a guess`},
+		{"row before a puzzle", "pack: Test\n..*\n", nil, "line 2"},
+		{"no pack name", "puzzle: A\n" + testPuzzleBoard, nil, "pack:"},
+		{"no puzzles", "pack: Test\n", nil, "no puzzles"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pack, err := ParsePuzzlePack(test.text)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, want one mentioning %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if pack.Name != "Test" || len(pack.Puzzles) != len(test.puzzles) {
+				t.Fatalf("got pack %q with %d puzzles, want \"Test\" with %d", pack.Name, len(pack.Puzzles), len(test.puzzles))
+			}
+			for i, name := range test.puzzles {
+				if pack.Puzzles[i].Name != name {
+					t.Errorf("puzzle %d is %q, want %q", i, pack.Puzzles[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultPuzzlePack(t *testing.T) {
+	pack, err := ParsePuzzlePack(defaultPuzzles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pack.Puzzles) == 0 {
+		t.Fatal("the built in pack has no puzzles")
+	}
+}
@@ -0,0 +1,302 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file converts replays to and from RAWVF (the raw video text format used by Minesweeper Arbiter, ViennaMine and
+the community analysis tools) so our games can be analysed elsewhere and community boards/videos can be watched and
+played here. A RAWVF file has "Key: value" header lines, a Board section with one line per row ('*' mine, '0' safe)
+and an Events section with one mouse event per line, e.g. "1.23 lc 4 2 (56 24)" (time in seconds, event, column and
+row counted from 1, then the pixel position on 16 pixel squares)
+- RAWVF has no undo, so undone moves are left out of an export, and it only knows mouse buttons so a reveal is a left
+click, a flag a right click and a chord a middle click
+
+Functions:
+- ExportRAWVF: Writes a replay as RAWVF
+
+- ImportRAWVF: Reads a RAWVF file into a replay
+
+- effectiveMoves: The moves of a replay with the undone ones taken out
+
+- rawvfLevel: The RAWVF level name for a board size
+
+- playHandler: Makes a new game on a replay's board, so an imported board can be played
+
+Inputs:
+- Replay / RAWVF text
+
+Outputs:
+- RAWVF text / Replay
+*/
+
+package components
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"minesweeper/config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Size in pixels of a square in RAWVF pixel positions (the classic game's square size)
+const rawvfSquare = 16
+
+// Writes a replay as RAWVF
+// Inputs: The replay and where to write it
+// Outputs: Error if writing failed
+func ExportRAWVF(rep Replay, out io.Writer) error {
+	if topologyNamed(rep.Grid).Name() != config.GridSquare {
+		return fmt.Errorf("RAWVF only holds games on the square grid")
+	}
+	if rep.CellMines > 1 {
+		return fmt.Errorf("RAWVF only holds games with at most one mine per cell")
+	}
+	if rep.Lives > 1 {
+		return fmt.Errorf("RAWVF only holds games that end at the first mine, not games with lives")
+	}
+	moves, layout := effectiveMoves(rep)
+	final := rep.handlerAt(len(rep.Moves))
+
+	w := bufio.NewWriter(out)
+	marks := "Off"
+	if rep.QuestionMarks {
+		marks = "On"
+	}
+	seconds := 0.0
+	if len(moves) > 0 {
+		seconds = float64(moves[len(moves)-1].At) / 1000
+	}
+	fmt.Fprintln(w, "RawVF_Version: Rev5")
+	fmt.Fprintln(w, "Program: Minesweeper 2")
+	fmt.Fprintln(w, "Timestamp:", rep.Started.Format(time.RFC3339))
+	fmt.Fprintln(w, "Level:", rawvfLevel(rep.Rows, rep.Cols, rep.Mines))
+	fmt.Fprintln(w, "Width:", rep.Cols)
+	fmt.Fprintln(w, "Height:", rep.Rows)
+	fmt.Fprintln(w, "Mines:", strings.Count(strings.Join(layout, ""), "*"))
+	fmt.Fprintln(w, "Marks:", marks)
+	fmt.Fprintln(w, "Mode: Classic")
+	fmt.Fprintf(w, "Time: %.2f\n", seconds)
+	fmt.Fprintln(w, "Board:")
+	for _, row := range layout {
+		fmt.Fprintln(w, strings.ReplaceAll(row, ".", "0"))
+	}
+
+	fmt.Fprintln(w, "Events:")
+	fmt.Fprintln(w, "0.00 start")
+	for _, m := range moves {
+		// A press and release of the button for the move on the same square
+		press, release := "lc", "lr"
+		switch m.Kind {
+		case MoveFlag, MovePlaceFlag:
+			press, release = "rc", "rr"
+		case MoveChord:
+			press, release = "mc", "mr"
+		}
+		x, y := m.Col+1, m.Row+1
+		at := float64(m.At) / 1000
+		px, py := m.Col*rawvfSquare+rawvfSquare/2, m.Row*rawvfSquare+rawvfSquare/2
+		fmt.Fprintf(w, "%.2f %s %d %d (%d %d)\n", at, press, x, y, px, py)
+		fmt.Fprintf(w, "%.2f %s %d %d (%d %d)\n", at, release, x, y, px, py)
+	}
+	if final.gameOver && final.win {
+		fmt.Fprintf(w, "%.2f won\n", seconds)
+	} else if final.gameOver {
+		fmt.Fprintf(w, "%.2f blast\n", seconds)
+	}
+	return w.Flush()
+}
+
+// Reads a RAWVF file into a replay. Squares are opened when the left button is let go, flags are placed when the right
+// button is pressed, and letting go of both buttons (or the middle button) chords, the same as the classic game
+// Inputs: The RAWVF text
+// Outputs: Replay of the file (single player), or an error saying what is wrong with the file
+func ImportRAWVF(in io.Reader) (Replay, error) {
+	rep := Replay{Mode: "Single", Option: "Play", Started: time.Now()}
+	var layout []string
+	section := ""
+	left, right := false, false // Buttons held down
+	scanner := bufio.NewScanner(in)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		switch {
+		case text == "Board:" || text == "Events:":
+			section = text
+			continue
+		case section == "Board:" && !strings.Contains(text, ":"):
+			layout = append(layout, strings.Map(func(r rune) rune {
+				if r == '*' {
+					return '*'
+				}
+				return '.'
+			}, text))
+			continue
+		case section != "Events:":
+			// Header line, only the ones needed to rebuild the game are used
+			key, value, _ := strings.Cut(text, ":")
+			value = strings.TrimSpace(value)
+			switch key {
+			case "Width":
+				rep.Cols, _ = strconv.Atoi(value)
+			case "Height":
+				rep.Rows, _ = strconv.Atoi(value)
+			case "Mines":
+				rep.Mines, _ = strconv.Atoi(value)
+			case "Marks":
+				rep.QuestionMarks = strings.EqualFold(value, "on")
+			case "Timestamp":
+				if started, err := time.Parse(time.RFC3339, value); err == nil {
+					rep.Started = started
+				}
+			}
+			section = ""
+			continue
+		}
+
+		// Event line: "time event [x y (px py)]"
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			return Replay{}, fmt.Errorf("line %d: expected an event, found %q", line, text)
+		}
+		seconds, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return Replay{}, fmt.Errorf("line %d: %q is not a time", line, fields[0])
+		}
+		x, y := 0, 0
+		if len(fields) >= 4 {
+			x, _ = strconv.Atoi(fields[2])
+			y, _ = strconv.Atoi(fields[3])
+		}
+		onBoard := x >= 1 && x <= rep.Cols && y >= 1 && y <= rep.Rows
+		kind := ""
+		switch fields[1] {
+		case "lc":
+			left = true
+		case "rc":
+			right = true
+			if !left {
+				kind = MoveFlag
+			}
+		case "lr", "rr":
+			if left && right {
+				kind = MoveChord
+			} else if fields[1] == "lr" && left {
+				kind = MoveReveal
+			}
+			if fields[1] == "lr" {
+				left = false
+			} else {
+				right = false
+			}
+		case "mr":
+			kind = MoveChord
+		}
+		if kind != "" && onBoard {
+			rep.Moves = append(rep.Moves, ReplayMove{At: int64(seconds * 1000), Kind: kind, Row: y - 1, Col: x - 1})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Replay{}, err
+	}
+
+	if rep.Rows < 1 || rep.Cols < 1 {
+		return Replay{}, fmt.Errorf("the Width/Height header is missing")
+	}
+	if rep.Rows > config.MaxBoardSize || rep.Cols > config.MaxBoardSize {
+		return Replay{}, fmt.Errorf("the board is bigger than the largest board (%dx%d)", config.MaxBoardSize, config.MaxBoardSize)
+	}
+	// The board is checked by putting it on a game (setMineLayout says which row is wrong)
+	h := NewSizedGameHandler(rep.Rows, rep.Cols, 0, 0)
+	if err := setMineLayout(&h, layout); err != nil {
+		return Replay{}, fmt.Errorf("board: %w", err)
+	}
+	rep.Mines = h.totalMines
+	for i := range rep.Moves {
+		if rep.Moves[i].Kind == MoveReveal {
+			rep.Moves[i].Layout = layout // The board is fixed from the first click on
+			break
+		}
+	}
+	final := rep.handlerAt(len(rep.Moves))
+	rep.Finished, rep.Won = final.gameOver, final.win
+	return rep, nil
+}
+
+// Takes the undone moves out of a replay: every undo removes the last player move that changed the board and the AI
+// moves after it, the same way Undo does in the game
+// Inputs: The replay
+// Outputs: The moves that are left, and the mine layout they were played on
+func effectiveMoves(rep Replay) ([]ReplayMove, []string) {
+	h := rep.newHandler()
+	kept := []ReplayMove{}
+	starts := []int{} // Where each undoable player move starts in kept
+	for _, m := range rep.Moves {
+		undoPoints := len(h.undo)
+		rep.apply(&h, m)
+		switch {
+		case m.Kind == MoveUndo:
+			if len(h.undo) < undoPoints {
+				kept = kept[:starts[len(starts)-1]]
+				starts = starts[:len(starts)-1]
+			}
+		case !m.AI:
+			if len(h.undo) > undoPoints { // A player move that did nothing has no undo point
+				starts = append(starts, len(kept))
+				kept = append(kept, m)
+			}
+		default:
+			kept = append(kept, m)
+		}
+	}
+
+	for _, m := range kept {
+		if m.Layout != nil {
+			return kept, m.Layout
+		}
+	}
+	// The game started from a saved board, or nothing was revealed so the mines were never placed for good and they
+	// are where the seed put them
+	if rep.Start != nil {
+		started := rep.newHandler()
+		return kept, mineLayout(&started)
+	}
+	seeded := NewSizedGameHandler(rep.Rows, rep.Cols, rep.Mines, rep.Seed)
+	seeded.setCellMines(rep.CellMines)
+	return kept, mineLayout(&seeded)
+}
+
+// Gives the RAWVF level name of a board, Custom unless it is one of the classic difficulties
+// Inputs: Board rows, cols and mine count
+// Outputs: Level name
+func rawvfLevel(rows int, cols int, mines int) string {
+	for _, p := range config.Presets {
+		if p.Rows == rows && p.Cols == cols && p.Mines == mines {
+			return p.Label
+		}
+	}
+	return "Custom"
+}
+
+// Makes a new game on the mines of a replay (the board the first click revealed) and its grid, so an imported board can
+// be played
+// Inputs: None
+// Outputs: gameHandler object with nothing revealed (see NewLayoutGameHandler), or an error if the board has no safe cell
+func (rep Replay) playHandler() (Gamehandler, error) {
+	_, layout := effectiveMoves(rep)
+	h, err := NewLayoutGameHandler(layout)
+	if err != nil {
+		return h, err
+	}
+	h.cellMines = max(h.cellMines, rep.CellMines)
+	h.setTopology(topologyNamed(rep.Grid))
+	return h, nil
+}
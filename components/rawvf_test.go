@@ -0,0 +1,94 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- Tests for the RAWVF export and import (rawvf.go)
+
+Functions:
+- TestRAWVFRoundTrip: A played game exported and read back gives the same moves and board
+
+- TestImportRAWVFErrors: Broken RAWVF files are refused
+
+Inputs:
+- None
+
+Outputs:
+- Test results
+*/
+
+package components
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRAWVFRoundTrip(t *testing.T) {
+	h := NewSizedGameHandler(9, 9, 10, 42)
+	h.Click(4, 4)
+	for r := 0; r < h.rows && !h.gameOver; r++ {
+		for c := 0; c < h.cols && !h.gameOver; c++ {
+			if sq := h.board[r][c]; sq.isBomb() && sq.state == Covered {
+				h.ToggleFlag(r, c)
+			} else if sq.state == Covered {
+				h.Click(r, c)
+			}
+		}
+	}
+	if !h.win {
+		t.Fatal("the game wasn't won, the test board is wrong")
+	}
+	rep := replayOf(&h)
+
+	var out bytes.Buffer
+	if err := ExportRAWVF(rep, &out); err != nil {
+		t.Fatal(err)
+	}
+	back, err := ImportRAWVF(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if back.Rows != rep.Rows || back.Cols != rep.Cols || back.Mines != rep.Mines {
+		t.Fatalf("board is %dx%d with %d mines, want %dx%d with %d", back.Cols, back.Rows, back.Mines, rep.Cols, rep.Rows, rep.Mines)
+	}
+	if len(back.Moves) != len(rep.Moves) {
+		t.Fatalf("%d moves read back, want %d", len(back.Moves), len(rep.Moves))
+	}
+	for i, m := range rep.Moves {
+		if got := back.Moves[i]; got.Kind != m.Kind || got.Row != m.Row || got.Col != m.Col {
+			t.Errorf("move %d is %s %d,%d, want %s %d,%d", i+1, got.Kind, got.Row, got.Col, m.Kind, m.Row, m.Col)
+		}
+	}
+	if !back.Finished || back.Won != h.win {
+		t.Errorf("read back as finished %v won %v, want finished won %v", back.Finished, back.Won, h.win)
+	}
+	final := back.handlerAt(len(back.Moves))
+	if got, want := encodeBoard(&final), encodeBoard(&h); !slices.Equal(got, want) {
+		t.Errorf("final board\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestImportRAWVFErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  string // Part of the error message
+	}{
+		{"no size", "RAWVF: Rev5\nBoard:\n*0\n", "Width/Height"},
+		{"too big", "Width: 100000\nHeight: 100000\nBoard:\n*0\n", "bigger than the largest board"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ImportRAWVF(strings.NewReader(test.text))
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("error = %v, want one mentioning %q", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is a plain text command-line mode for the game. Moves are typed as a command plus a coordinate
+(r c4 reveals, f d7 flags, c e5 chords) and the board is printed as ASCII after every move. Because it only reads
+lines from stdin, a file of moves can be piped in to play a scripted game, which is how Click/ToggleFlag behaviour
+can be checked against a known board (start the script with "new <mines> <seed>", or use the --seed/--mines/--size
+flags, so the board is always the same). Games can be saved and loaded with the save file from savegame.go, and
+boards written by hand can be loaded and any board exported as a layout (layout.go)
+
+Functions:
+- RunREPL: Reads commands until the input ends or quit is typed, printing the board after each move
+
+- runCommand: Runs a single command line against the current game
+
+- printBoard: Prints the board with its a-j/1-n headers and a status line
+
+Inputs:
+- Lines of commands (typed or piped in)
+
+Outputs:
+- ASCII board and messages after each command
+*/
+
+package components
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"minesweeper/config"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Help text printed by the help command
+const replHelp = `commands:
+  r <cell>             reveal a cell, e.g. r c4
+  f <cell>             flag/unflag a cell, e.g. f d7
+  c <cell>             chord around a number, e.g. c e5
+  new [mines] [seed]   start a new game (same mines + seed = same board)
+  save <file>          save the game
+  load <file>          continue a saved game, or play a board layout/RAWVF file
+  export <file> [list] write the board's mines as a layout grid (or a list)
+  board                print the board again
+  help                 show this help
+  quit                 leave
+lines starting with # are ignored`
+
+// Runs the text mode until the input runs out or the user quits
+// Inputs: Where commands are read from, where the board is written to and the game flags from the command line
+// Outputs: Error if the flags are wrong or reading the input failed
+func RunREPL(in io.Reader, out io.Writer, launch LaunchOptions) error {
+	// Only show a prompt when someone is typing, piped commands get echoed instead so the output reads like a transcript
+	interactive := false
+	if f, ok := in.(*os.File); ok {
+		_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+		interactive = err == nil
+	}
+
+	// Scripts normally start with "new <mines> <seed>", so the random starting board is only shown to people typing
+	// (or when the game was picked with flags)
+	h := NewGameHandler(config.Current.Mines)
+	if launch.Wanted() {
+		var err error
+		if h, err = launch.NewGame(); err != nil {
+			return err
+		}
+		if h.aiEnabled || h.aiSolver || h.hotseat != nil || h.infinite != nil {
+			return fmt.Errorf("--repl only plays single player games on a fixed board, leave out --mode/--ai")
+		}
+		interactive = true
+	}
+	if interactive {
+		fmt.Fprintf(out, "new game: %d mines, seed %d (type help for the commands)\n", h.totalMines, h.seed)
+		printBoard(out, &h)
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		if interactive {
+			fmt.Fprint(out, "> ")
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !interactive {
+			fmt.Fprintln(out, ">", line)
+		}
+		if !runCommand(out, &h, line) {
+			return nil
+		}
+	}
+}
+
+// Runs one command line on the game
+// Inputs: Output to print to, the game (can be replaced by "new") and the command line
+// Outputs: false once the user asked to quit
+func runCommand(out io.Writer, h *Gamehandler, line string) bool {
+	fields := strings.Fields(strings.ToLower(line))
+	switch fields[0] {
+	case "q", "quit", "exit":
+		return false
+	case "help", "?":
+		fmt.Fprintln(out, replHelp)
+		return true
+	case "board", "p":
+		printBoard(out, h)
+		return true
+	case "new", "n":
+		mines := h.totalMines
+		seed := time.Now().UnixNano()
+		if len(fields) > 1 {
+			n, err := parseMineCount(fields[1])
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				return true
+			}
+			mines = n
+		}
+		if len(fields) > 2 {
+			s, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				fmt.Fprintln(out, "error: seed must be a whole number")
+				return true
+			}
+			seed = s
+		}
+		grid, cellMines, lives := h.topology, h.cellMines, h.startLives
+		mode, option := handlerMode(h)
+		*h = NewSizedGameHandler(h.rows, h.cols, mines, seed)
+		h.setCellMines(cellMines)
+		h.setLives(lives)
+		applyMode(h, mode, option)
+		h.setTopology(grid)
+		fmt.Fprintf(out, "new game: %d mines, seed %d\n", h.totalMines, h.seed)
+		printBoard(out, h)
+		return true
+	case "save", "load":
+		if len(fields) != 2 {
+			fmt.Fprintf(out, "error: expected a file name, e.g. %s game.json\n", fields[0])
+			return true
+		}
+		path := strings.Fields(line)[1] // Keep the file name's case
+		if fields[0] == "save" {
+			if err := SaveGame(h, path); err != nil {
+				fmt.Fprintln(out, "error:", err)
+			} else {
+				fmt.Fprintln(out, "saved to", path)
+			}
+			return true
+		}
+		loaded, err := loadBoardFile(path)
+		if err != nil {
+			fmt.Fprintln(out, "error:", err)
+			return true
+		}
+		*h = loaded
+		printBoard(out, h)
+		return true
+	case "export":
+		if len(fields) < 2 || len(fields) > 3 || (len(fields) == 3 && fields[2] != "list") {
+			fmt.Fprintln(out, "error: expected a file name and maybe list, e.g. export board.txt list")
+			return true
+		}
+		path := strings.Fields(line)[1]
+		if err := SaveLayout(h, path, len(fields) == 3); err != nil {
+			fmt.Fprintln(out, "error:", err)
+		} else {
+			fmt.Fprintln(out, "board written to", path)
+		}
+		return true
+	}
+
+	// Everything else is a move on a cell
+	if len(fields) != 2 {
+		fmt.Fprintln(out, "error: expected a command and a cell, e.g. r c4 (type help for the list)")
+		return true
+	}
+	row, col, ok := parseCoordinate(fields[1])
+	if !ok || !isiInbounds(h, row, col) {
+		fmt.Fprintf(out, "error: %q is not a cell on this board\n", fields[1])
+		return true
+	}
+	if h.checkClock() {
+		printBoard(out, h) // The time ran out before this move
+		return true
+	}
+	if h.gameOver {
+		fmt.Fprintln(out, "error: the game is over, type new to play again")
+		return true
+	}
+
+	switch fields[0] {
+	case "r", "reveal":
+		if h.board[row][col].state == Flagged {
+			fmt.Fprintf(out, "error: %s is flagged, unflag it first\n", cellName(row, col))
+			return true
+		}
+		if !h.board[row][col].isCovered() { // A question mark is still a covered cell
+			fmt.Fprintf(out, "error: %s is not covered\n", cellName(row, col))
+			return true
+		}
+		h.Click(row, col)
+	case "f", "flag":
+		if h.board[row][col].state == Uncovered {
+			fmt.Fprintf(out, "error: %s is already uncovered\n", cellName(row, col))
+			return true
+		}
+		h.ToggleFlag(row, col)
+	case "c", "chord":
+		if !h.Chord(row, col) {
+			fmt.Fprintf(out, "error: %s can't be chorded (needs a number with exactly that many flags around it)\n", cellName(row, col))
+			return true
+		}
+	default:
+		fmt.Fprintf(out, "error: unknown command %q (type help for the list)\n", fields[0])
+		return true
+	}
+	printBoard(out, h)
+	return true
+}
+
+// Prints the board as ASCII with the column letters on top and row numbers down the side, then a status line. On the
+// hex grid the odd rows are pushed half a cell to the right like on the window's board, a wrap-around board gets a ~
+// after each row and under each column to show those edges join the other side. The columns are two characters wide
+// when the neighbor rule allows numbers of 10 and up
+// Inputs: Output to print to and the game
+// Outputs: None
+func printBoard(out io.Writer, h *Gamehandler) {
+	width := symbolWidth(h)
+	fmt.Fprint(out, "   ")
+	for c := 0; c < h.cols; c++ {
+		fmt.Fprintf(out, " %*s", width, columnLabel(c))
+	}
+	fmt.Fprintln(out)
+	for r := 0; r < h.rows; r++ {
+		fmt.Fprintf(out, "%3d", r+1)
+		if h.gridName() == config.GridHex && r%2 == 1 {
+			fmt.Fprint(out, " ")
+		}
+		for c := 0; c < h.cols; c++ {
+			fmt.Fprintf(out, " %*s", width, cellSymbol(h.board[r][c]))
+		}
+		if h.gridName() == config.GridTorus {
+			fmt.Fprint(out, " ~")
+		}
+		fmt.Fprintln(out)
+	}
+	if h.gridName() == config.GridTorus {
+		fmt.Fprintln(out, "   "+strings.Repeat(" ~", h.cols))
+	}
+
+	status := "playing"
+	if h.gameOver && h.win {
+		status = "you win"
+	} else if h.limitReached && h.timeLimit > 0 {
+		status = "time's up"
+	} else if h.limitReached {
+		status = "out of moves"
+	} else if h.gameOver {
+		status = "game over"
+	}
+	for _, text := range []string{scoreText(h), livesText(h)} {
+		if text != "" {
+			status = strings.ToLower(text) + "  " + status
+		}
+	}
+	fmt.Fprintf(out, "mines: %d  flags: %d  %s\n", h.totalMines, h.flagCount(), status)
+}
@@ -0,0 +1,366 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the replay viewer. It plays a recorded game (replay.go) back on a read-only board widget with play,
+pause, step, seek and speed controls. Moves are played with the same gaps between them as in the game (sped up or
+slowed down by the speed, long thinking pauses are cut short). It is opened from the game over message or from the
+Replays list on the title screen. Replays can be exported to and imported from RAWVF (rawvf.go), and the board of a
+replay can be played as a new game
+
+Functions:
+- showReplayList: Shows the saved replays, newest first
+
+- showReplay: Shows the replay viewer for one replay
+
+- importReplay/exportReplay: Ask for a RAWVF file and read it into the viewer, or write the replay to one
+
+- seek/step/play/pause/scheduleNext: Move the replay to a given move, one move on, or start/stop playing it
+
+- show: Updates the slider and the move description after the replay moved
+
+- describeMove: Describes a recorded move in words
+
+- replayTitle: One line summary of a replay (date, board, mode and result)
+
+Inputs:
+- Replay to watch and the controls
+
+Outputs:
+- The game drawn at the chosen move
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Longest wait between two moves when playing, so a long think in the game doesn't stall the replay
+const maxReplayGap = 2 * time.Second
+
+// Replay speeds offered by the viewer
+var replaySpeeds = map[string]float64{"0.5x": 0.5, "1x": 1, "2x": 2, "4x": 4, "8x": 8}
+
+// replayPlayer is the state of one replay viewer
+type replayPlayer struct {
+	rep     Replay
+	handler Gamehandler // The game after pos moves, the board widget draws this
+	pos     int         // Number of moves made
+	playing bool
+	speed   float64
+	timer   *time.Timer // Next move while playing
+
+	board      *BoardWidget
+	slider     *widget.Slider
+	moveLabel  *widget.Label
+	playButton *widget.Button
+}
+
+// Shows the saved replays, tapping one opens it in the viewer
+// Inputs: the fyne window itself
+// Outputs: None, replaces the window content
+func showReplayList(win fyne.Window) {
+	backButton := widget.NewButton("Back", func() {
+		LoadSetupInto(win)
+	})
+
+	importButton := widget.NewButton("Import RAWVF", func() {
+		importReplay(win)
+	})
+
+	paths, err := ListReplays()
+	if err != nil || len(paths) == 0 {
+		text := "No replays yet, every finished game is saved here."
+		if err != nil {
+			text = "Could not find the replays: " + err.Error()
+		}
+		win.SetContent(container.NewPadded(container.NewVBox(widget.NewLabel(text), importButton, backButton)))
+		return
+	}
+
+	list := widget.NewList(
+		func() int { return len(paths) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, obj fyne.CanvasObject) {
+			rep, err := LoadReplay(paths[i])
+			if err != nil {
+				obj.(*widget.Label).SetText(filepath.Base(paths[i]) + ": " + err.Error())
+				return
+			}
+			obj.(*widget.Label).SetText(replayTitle(rep))
+		},
+	)
+	list.OnSelected = func(i widget.ListItemID) {
+		rep, err := LoadReplay(paths[i])
+		if err != nil {
+			list.UnselectAll()
+			return
+		}
+		showReplay(win, rep, func() { showReplayList(win) })
+	}
+
+	win.SetContent(container.NewPadded(container.NewBorder(widget.NewLabel("Replays:"),
+		container.NewVBox(importButton, backButton), nil, nil, list)))
+}
+
+// Shows the replay viewer, starting from the empty board
+// Inputs: the fyne window, the replay and what the Back button should do
+// Outputs: None, replaces the window content
+func showReplay(win fyne.Window, rep Replay, back func()) {
+	p := &replayPlayer{rep: rep, handler: rep.newHandler(), speed: 1}
+	p.board = NewBoardWidget(&p.handler)
+	p.board.ReadOnly = true
+	p.moveLabel = widget.NewLabel("")
+
+	p.slider = widget.NewSlider(0, float64(max(len(rep.Moves), 1)))
+	p.slider.OnChanged = func(v float64) {
+		if int(v) != p.pos {
+			p.seek(int(v))
+		}
+	}
+
+	p.playButton = widget.NewButtonWithIcon("", theme.MediaPlayIcon(), func() {
+		if p.playing {
+			p.pause()
+		} else {
+			p.play()
+		}
+	})
+	stepBack := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() {
+		p.pause()
+		p.seek(p.pos - 1)
+	})
+	stepForward := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() {
+		p.pause()
+		p.step()
+	})
+	speedSelect := widget.NewSelect([]string{"0.5x", "1x", "2x", "4x", "8x"}, func(name string) {
+		p.speed = replaySpeeds[name]
+	})
+	speedSelect.SetSelected("1x")
+	backButton := widget.NewButton("Back", func() {
+		p.pause()
+		back()
+	})
+	exportButton := widget.NewButton("Export RAWVF", func() {
+		exportReplay(win, rep)
+	})
+	if topologyNamed(rep.Grid).Name() != config.GridSquare || rep.CellMines > 1 || rep.Lives > 1 {
+		exportButton.Disable() // RAWVF only holds square boards with one mine per cell that end at the first mine
+	}
+	playBoardButton := widget.NewButton("Play Board", func() {
+		p.pause()
+		h, err := rep.playHandler()
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		ShowGame(win, &h)
+	})
+	title := widget.NewLabel(replayTitle(rep))
+	title.Wrapping = fyne.TextWrapWord
+
+	controls := container.NewVBox(
+		p.moveLabel,
+		p.slider,
+		container.NewHBox(backButton, stepBack, p.playButton, stepForward, speedSelect),
+	)
+	p.show()
+	top := container.NewBorder(nil, nil, nil, container.NewHBox(exportButton, playBoardButton), title)
+	win.SetContent(container.NewBorder(top, controls, nil, nil, p.board.inScroll()))
+}
+
+// Asks for a RAWVF file and shows it in the replay viewer
+// Inputs: the fyne window itself
+// Outputs: None, shows an error dialog if the file could not be read
+func importReplay(win fyne.Window) {
+	dialog.ShowFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if file == nil {
+			return // Cancelled
+		}
+		defer file.Close()
+		rep, err := ImportRAWVF(file)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", file.URI().Name(), err), win)
+			return
+		}
+		showReplay(win, rep, func() { showReplayList(win) })
+	}, win)
+}
+
+// Asks where to save a RAWVF file and writes the replay to it
+// Inputs: the fyne window and the replay
+// Outputs: None, shows an error dialog if the file could not be written
+func exportReplay(win fyne.Window, rep Replay) {
+	dialog.ShowFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if file == nil {
+			return // Cancelled
+		}
+		defer file.Close()
+		if err := ExportRAWVF(rep, file); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+}
+
+// Puts the replay at a move by playing the moves from the start again
+// Inputs: Number of moves to have made
+// Outputs: None, redraws the board
+func (p *replayPlayer) seek(moves int) {
+	moves = min(max(moves, 0), len(p.rep.Moves))
+	p.handler = p.rep.handlerAt(moves)
+	p.pos = moves
+	p.show()
+}
+
+// Makes the next move
+// Inputs: None
+// Outputs: False if the replay was already at the end
+func (p *replayPlayer) step() bool {
+	if p.pos >= len(p.rep.Moves) {
+		return false
+	}
+	p.rep.apply(&p.handler, p.rep.Moves[p.pos])
+	p.pos++
+	p.show()
+	return true
+}
+
+// Starts playing from the current move, from the start again if the replay was at the end
+func (p *replayPlayer) play() {
+	if p.pos >= len(p.rep.Moves) {
+		p.seek(0)
+	}
+	p.playing = true
+	p.playButton.SetIcon(theme.MediaPauseIcon())
+	p.scheduleNext()
+}
+
+// Stops playing, the replay stays at the current move
+func (p *replayPlayer) pause() {
+	p.playing = false
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.playButton.SetIcon(theme.MediaPlayIcon())
+}
+
+// Waits as long as the game did before the next move (divided by the speed) and then makes it
+func (p *replayPlayer) scheduleNext() {
+	if p.pos >= len(p.rep.Moves) {
+		p.pause()
+		return
+	}
+	gap := p.rep.Moves[p.pos].At
+	if p.pos > 0 {
+		gap -= p.rep.Moves[p.pos-1].At
+	}
+	wait := min(time.Duration(float64(gap)/p.speed)*time.Millisecond, maxReplayGap)
+	var timer *time.Timer
+	timer = time.AfterFunc(wait, func() {
+		fyne.Do(func() {
+			if p.timer != timer {
+				return // Paused (and maybe played again) since this was scheduled
+			}
+			if p.playing && p.step() {
+				p.scheduleNext()
+			} else {
+				p.pause()
+			}
+		})
+	})
+	p.timer = timer
+}
+
+// Redraws the board and updates the slider and move description
+func (p *replayPlayer) show() {
+	p.board.Refresh()
+	p.slider.SetValue(float64(p.pos))
+	if p.pos == 0 {
+		p.moveLabel.SetText(fmt.Sprintf("Move 0 of %d", len(p.rep.Moves)))
+		return
+	}
+	m := p.rep.Moves[p.pos-1]
+	at := time.Duration(m.At) * time.Millisecond
+	p.moveLabel.SetText(fmt.Sprintf("Move %d of %d (%d:%02d): %s", p.pos, len(p.rep.Moves),
+		int(at.Minutes()), int(at.Seconds())%60, describeMove(m)))
+}
+
+// Describes a recorded move, e.g. "You revealed c4" or "AI flagged d7"
+// Inputs: The move
+// Outputs: Description
+func describeMove(m ReplayMove) string {
+	who := "You"
+	if m.AI {
+		who = "AI"
+	} else if m.Player != "" {
+		who = m.Player
+	}
+	switch m.Kind {
+	case MoveReveal:
+		return who + " revealed " + cellName(m.Row, m.Col)
+	case MoveFlag:
+		return who + " changed the flag on " + cellName(m.Row, m.Col)
+	case MovePlaceFlag:
+		return who + " flagged " + cellName(m.Row, m.Col)
+	case MoveChord:
+		return who + " chorded " + cellName(m.Row, m.Col)
+	case MoveUndo:
+		return who + " undid the last move"
+	case MoveTimeUp:
+		return "The time ran out"
+	}
+	return who + " made an unknown move"
+}
+
+// Gives a one line summary of a replay for the list and the viewer, e.g. "2026-10-19 15:04, 16x16 with 40 mines,
+// single player, won"
+func replayTitle(rep Replay) string {
+	mode := "single player"
+	switch rep.Mode {
+	case "AI":
+		mode = "1v1 against the " + strings.ToLower(rep.Option) + " AI"
+	case "Solve":
+		mode = strings.ToLower(rep.Option) + " AI solver"
+	case "Timed":
+		mode = "time attack (" + limitTitle(rep.Mode, rep.Option) + ")"
+	case "Moves":
+		mode = "move budget (" + limitTitle(rep.Mode, rep.Option) + ")"
+	case "Infinite":
+		mode = "infinite"
+	case "Hotseat":
+		names := hotseatNames(rep.Option)
+		mode = "hot-seat, " + names[0] + " vs " + names[1]
+	}
+	result := "not finished"
+	if rep.Finished && rep.Won {
+		result = "won"
+	} else if rep.Finished {
+		result = "lost"
+	}
+	return fmt.Sprintf("%s, %dx%d with %d mines, %s, %s", rep.Started.Local().Format("2006-01-02 15:04"),
+		rep.Cols, rep.Rows, rep.Mines, mode, result)
+}
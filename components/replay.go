@@ -0,0 +1,349 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file records every game as a list of timestamped moves so it can be watched again in the replay viewer
+(replay-viewer.go). The game handler records each reveal, flag, chord and undo as it happens, the first click also
+records where the mines ended up after the first click policy moved them, so playing the moves back on a board with
+that layout always gives the same game. Finished games are saved as JSON in the replays folder next to the settings file
+
+Functions:
+- record: Adds a move to the game handler's recording
+
+- playTimeMs: How long a game has been played, counted from its first reveal
+
+- mineLayout/setMineLayout: Turn the mines on a board into rows of '*' (mine) and '.' (no mine) and back, a cell with
+several mines (the multi-mine game) is written as its mine count
+
+- replayOf: Makes the replay of a game handler
+
+- SaveReplay/LoadReplay: Write a replay to the replays folder and read one back
+
+- ListReplays: Finds the saved replays, newest first
+
+- newHandler/apply/handlerAt: Rebuild the game from a replay, one move at a time or up to a given move
+
+Inputs:
+- Moves from the game handler / replay files
+
+Outputs:
+- Replay files / game handlers showing the game at any move
+*/
+
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"minesweeper/config"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of recorded moves
+const (
+	MoveReveal    = "reveal"     // Click
+	MoveFlag      = "flag"       // ToggleFlag
+	MovePlaceFlag = "place_flag" // Flag (the AIs)
+	MoveChord     = "chord"      // Chord
+	MoveUndo      = "undo"       // Undo
+	MoveTimeUp    = "time_up"    // The countdown of a Time Attack game ran out (scoremode.go)
+)
+
+// ReplayMove is one recorded move
+type ReplayMove struct {
+	At     int64    `json:"at_ms"` // Milliseconds since the game started
+	Kind   string   `json:"kind"`  // One of the Move kinds
+	Row    int      `json:"row"`
+	Col    int      `json:"col"`
+	AI     bool     `json:"ai,omitempty"`     // Made by the AI (1v1 or solver)
+	Player string   `json:"player,omitempty"` // Name of the hot-seat player who made it (hotseat.go)
+	Layout []string `json:"layout,omitempty"` // Mines after a first click, see mineLayout
+}
+
+// Replay is a whole recorded game, also the replay file format
+type Replay struct {
+	Rows          int          `json:"rows"`
+	Cols          int          `json:"cols"`
+	Mines         int          `json:"mines"`
+	Seed          int64        `json:"seed"`
+	Mode          string       `json:"mode"`   // "Single", "AI", "Solve", "Timed", "Moves" or "Hotseat"
+	Option        string       `json:"option"` // AI difficulty, the time or move limit, the hot-seat players, or "Play"
+	QuestionMarks bool         `json:"question_marks"`
+	Grid          string       `json:"grid,omitempty"`       // Board topology (config.Grid...), empty in replays from before there was a choice
+	CellMines     int          `json:"cell_mines,omitempty"` // Most mines a cell can hold, empty for the classic game of one
+	Lives         int          `json:"lives,omitempty"`      // Lives the game started with, empty for the classic game of one
+	Started       time.Time    `json:"started"`
+	Finished      bool         `json:"finished"`
+	Won           bool         `json:"won"`
+	Start         []string     `json:"start,omitempty"` // Board the game started from (save file letters) if it didn't start covered
+	Moves         []ReplayMove `json:"moves"`
+}
+
+// Adds a move to the recording, timed from when the game started
+// Inputs: gameHandler object, kind of move and its cell
+// Outputs: None
+func (handler *Gamehandler) record(kind string, row int, col int) {
+	handler.moves = append(handler.moves, ReplayMove{
+		At:   time.Since(handler.started).Milliseconds(),
+		Kind: kind,
+		Row:  row,
+		Col:  col,
+		AI:   handler.aiMoving,
+	})
+	if handler.hotseat != nil {
+		handler.moves[len(handler.moves)-1].Player = handler.hotseat.names[handler.hotseat.turn]
+	}
+}
+
+// Gives how long the game has been played, counted from the first reveal so time spent looking at the covered board
+// doesn't count (the daily leaderboard and the best times use this)
+// Inputs: gameHandler object
+// Outputs: Milliseconds, 0 before the first reveal
+func (handler *Gamehandler) playTimeMs() int64 {
+	for _, m := range handler.moves {
+		if m.Kind == MoveReveal {
+			return time.Since(handler.started).Milliseconds() - m.At
+		}
+	}
+	return 0
+}
+
+// Gives the mines on the board as one string per row, '*' for a mine and '.' for no mine. A cell with more than one
+// mine is written as the digit of its mine count
+// Inputs: gameHandler object
+// Outputs: Row strings
+func mineLayout(handler *Gamehandler) []string {
+	rows := make([]string, handler.rows)
+	for r := range rows {
+		row := strings.Builder{}
+		for c := 0; c < handler.cols; c++ {
+			switch bombs := handler.board[r][c].bombs; {
+			case bombs > 1:
+				row.WriteString(strconv.Itoa(bombs))
+			case bombs == 1:
+				row.WriteByte('*')
+			default:
+				row.WriteByte('.')
+			}
+		}
+		rows[r] = row.String()
+	}
+	return rows
+}
+
+// Puts the mines from a layout on the board (cell states are left alone) and works the numbers out again, a game whose
+// cells hold fewer mines than a digit in the layout is changed to hold that many
+// Inputs: gameHandler object and the row strings from mineLayout
+// Outputs: Error if the layout doesn't fit the board or has a letter other than '*', '.' and the digits 2-9
+func setMineLayout(handler *Gamehandler, layout []string) error {
+	if len(layout) != handler.rows {
+		return fmt.Errorf("expected %d layout rows, found %d", handler.rows, len(layout))
+	}
+	for r, text := range layout {
+		if len(text) != handler.cols {
+			return fmt.Errorf("layout row %d should have %d cells, found %d", r+1, handler.cols, len(text))
+		}
+		if strings.Trim(text, "*.23456789") != "" {
+			return fmt.Errorf("layout row %d can only have '*', '.' and mine counts", r+1)
+		}
+	}
+	handler.totalMines = 0
+	for r, text := range layout {
+		for c := range text {
+			sq := &handler.board[r][c]
+			switch text[c] {
+			case '*':
+				sq.bombs = 1
+			case '.':
+				sq.bombs = 0
+			default:
+				sq.bombs = int(text[c] - '0')
+			}
+			handler.totalMines += sq.bombs
+			handler.cellMines = max(handler.cellMines, sq.bombs)
+		}
+	}
+	handler.AddNumbers()
+	return nil
+}
+
+// Makes the replay of a game, the game can still be going
+// Inputs: gameHandler object
+// Outputs: Replay
+func replayOf(handler *Gamehandler) Replay {
+	mode, option := handlerMode(handler)
+	return Replay{
+		Rows:          handler.rows,
+		Cols:          handler.cols,
+		Mines:         handler.totalMines,
+		Seed:          handler.seed,
+		Mode:          mode,
+		Option:        option,
+		QuestionMarks: handler.questionMarks,
+		Grid:          handler.gridName(),
+		CellMines:     savedCellMines(handler),
+		Lives:         savedLives(handler),
+		Started:       handler.started,
+		Finished:      handler.gameOver,
+		Won:           handler.win,
+		Start:         slices.Clone(handler.start),
+		Moves:         slices.Clone(handler.moves),
+	}
+}
+
+// Gives the folder replays are saved in, next to the settings file
+func replayDir() (string, error) {
+	path, err := config.SettingsPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "replays"), nil
+}
+
+// Saves a replay in the replays folder, named after the time the game started so saving the same game again (e.g. it
+// ended again after an undo) replaces the older file
+// Inputs: The replay
+// Outputs: Path of the file, or an error if it could not be written
+func SaveReplay(rep Replay) (string, error) {
+	dir, err := replayDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, rep.Started.Format("2006-01-02_15-04-05.000")+".json")
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Reads a replay file
+// Inputs: The file path
+// Outputs: The replay, or an error saying what is wrong with the file
+func LoadReplay(path string) (Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Replay{}, err
+	}
+	var rep Replay
+	if err := json.Unmarshal(data, &rep); err != nil {
+		return Replay{}, fmt.Errorf("%s is not a replay: %w", path, err)
+	}
+	if rep.Rows < 1 || rep.Cols < 1 {
+		return Replay{}, fmt.Errorf("%s: the board size is missing", path)
+	}
+	if rep.Rows > config.MaxBoardSize || rep.Cols > config.MaxBoardSize {
+		return Replay{}, fmt.Errorf("%s: the board is bigger than the largest board (%dx%d)", path, config.MaxBoardSize, config.MaxBoardSize)
+	}
+	for i, m := range rep.Moves {
+		if m.Kind != MoveUndo && (m.Row < 0 || m.Row >= rep.Rows || m.Col < 0 || m.Col >= rep.Cols) {
+			return Replay{}, fmt.Errorf("%s: move %d is outside the board", path, i+1)
+		}
+	}
+	return rep, nil
+}
+
+// Finds the saved replays
+// Inputs: None
+// Outputs: Paths of the replay files, newest first (none if nothing was saved yet)
+func ListReplays() ([]string, error) {
+	dir, err := replayDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths) // The names are start times so this is oldest first
+	slices.Reverse(paths)
+	return paths, nil
+}
+
+// Creates the board the replay starts from, the mines are put down by the first click's move (see apply) unless the
+// game started from a saved or puzzle board
+// Inputs: None
+// Outputs: gameHandler object with no moves made
+func (rep Replay) newHandler() Gamehandler {
+	h := NewSizedGameHandler(rep.Rows, rep.Cols, 0, rep.Seed)
+	h.firstClickPolicy = config.FirstClickNone
+	h.questionMarks = rep.QuestionMarks
+	h.totalMines = rep.Mines
+	h.topology = topologyNamed(rep.Grid)
+	h.cellMines = max(rep.CellMines, 1)
+	h.setLives(rep.Lives)
+	if rep.Start != nil && decodeBoard(&h, rep.Start) == nil {
+		h.AddNumbers()
+		h.firstClick = false
+		h.lives = h.startLives
+		h.livesFromBoard()
+	}
+	if rep.Mode == "Timed" || rep.Mode == "Moves" || rep.Mode == "Hotseat" {
+		// The move budget ends the game the same way, running out of time is a move and the turns pass the same way
+		applyMode(&h, rep.Mode, rep.Option)
+	}
+	return h
+}
+
+// Makes one recorded move, player moves save an undo point first the same way the front-ends do so undo moves take
+// back the same moves they did in the game
+// Inputs: gameHandler object of the replay and the move
+// Outputs: None, changes the handler
+func (rep Replay) apply(h *Gamehandler, m ReplayMove) {
+	if m.Kind == MoveUndo {
+		h.Undo()
+		return
+	}
+	if m.Kind == MoveTimeUp {
+		h.endByLimit()
+		return
+	}
+	if !m.AI {
+		h.saveUndo()
+	}
+	if m.Layout != nil && setMineLayout(h, m.Layout) == nil {
+		h.firstClick = false
+	}
+	switch m.Kind {
+	case MoveReveal:
+		if m.AI && h.board[m.Row][m.Col].isCovered() {
+			h.board[m.Row][m.Col].markedByAI = true
+		}
+		h.Click(m.Row, m.Col)
+	case MoveFlag:
+		h.ToggleFlag(m.Row, m.Col)
+	case MovePlaceFlag:
+		if m.AI && h.board[m.Row][m.Col].isCovered() {
+			h.board[m.Row][m.Col].markedByAI = true
+		}
+		h.Flag(m.Row, m.Col)
+	case MoveChord:
+		h.Chord(m.Row, m.Col)
+	}
+	// A player move that changed nothing has no undo point in the game either
+	if !m.AI && slices.EqualFunc(h.undo[len(h.undo)-1].board, h.board, slices.Equal) {
+		h.dropUndo()
+	}
+}
+
+// Rebuilds the game as it was after a number of moves
+// Inputs: Number of moves to make (0 is the empty board)
+// Outputs: gameHandler object at that point of the game
+func (rep Replay) handlerAt(moves int) Gamehandler {
+	h := rep.newHandler()
+	for _, m := range rep.Moves[:min(max(moves, 0), len(rep.Moves))] {
+		rep.apply(&h, m)
+	}
+	return h
+}
@@ -0,0 +1,194 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file saves a game in progress to a JSON file and loads it back, so a game can be continued later or started
+straight from the command line with --load. The board is stored one string per row with one letter per cell:
+	.  covered          *  covered mine
+	o  uncovered        x  uncovered mine (the game was lost)
+	f  flagged          F  flagged mine
+	q  question mark    Q  question marked mine
+When cells can hold several mines (multimine.go) every letter is followed by two digits, the cell's mine count and
+its flag count, e.g. "F21" is a cell with two mines and one flag on it
+In a lives game (lives.go) the uncovered mines are the ones that went off, each of them took a life.
+The numbers are worked out again when loading, and the game mode is not saved (pick it with --mode when loading)
+
+Functions:
+- SaveGame: Writes a game to a file
+
+- LoadGame: Reads a game back from a file
+
+- encodeBoard/decodeBoard: Turn the board into the row strings and back
+
+Inputs:
+- Game handler / save file
+
+Outputs:
+- Save file / game handler
+*/
+
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"minesweeper/config"
+	"os"
+	"strings"
+)
+
+// What is stored in a save file
+type savedGame struct {
+	Rows       int      `json:"rows"`
+	Cols       int      `json:"cols"`
+	Mines      int      `json:"mines"`
+	Seed       int64    `json:"seed"`
+	FirstClick bool     `json:"first_click"`          // Whether the first click (and its protection) is still to come
+	Grid       string   `json:"grid,omitempty"`       // Board topology (config.Grid...), empty in files from before there was a choice
+	CellMines  int      `json:"cell_mines,omitempty"` // Most mines a cell can hold, empty for the classic game of one
+	Lives      int      `json:"lives,omitempty"`      // Lives the game started with, empty for the classic game of one
+	Board      []string `json:"board"`
+}
+
+// Writes a game to a save file
+// Inputs: gameHandler object and the file path
+// Outputs: Error if the file could not be written
+func SaveGame(handler *Gamehandler, path string) error {
+	data, err := json.MarshalIndent(savedGame{
+		Rows:       handler.rows,
+		Cols:       handler.cols,
+		Mines:      handler.totalMines,
+		Seed:       handler.seed,
+		FirstClick: handler.firstClick,
+		Grid:       handler.gridName(),
+		CellMines:  savedCellMines(handler),
+		Lives:      savedLives(handler),
+		Board:      encodeBoard(handler),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Reads a game from a save file
+// Inputs: The file path
+// Outputs: gameHandler object of the saved game (single player, the caller can turn on an AI mode), or an error saying
+// what is wrong with the file
+func LoadGame(path string) (Gamehandler, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Gamehandler{}, err
+	}
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return Gamehandler{}, fmt.Errorf("%s is not a save file: %w", path, err)
+	}
+	if saved.Rows < 1 || saved.Cols < 1 {
+		return Gamehandler{}, fmt.Errorf("%s: the board size is missing", path)
+	}
+	if saved.Rows > config.MaxBoardSize || saved.Cols > config.MaxBoardSize {
+		return Gamehandler{}, fmt.Errorf("%s: the board is bigger than the largest board (%dx%d)", path, config.MaxBoardSize, config.MaxBoardSize)
+	}
+	if len(saved.Board) != saved.Rows {
+		return Gamehandler{}, fmt.Errorf("%s: expected %d board rows, found %d", path, saved.Rows, len(saved.Board))
+	}
+
+	handler := NewSizedGameHandler(saved.Rows, saved.Cols, 0, saved.Seed)
+	handler.cellMines = min(max(saved.CellMines, 1), config.MaxCellMines)
+	handler.setLives(saved.Lives)
+	if err := decodeBoard(&handler, saved.Board); err != nil {
+		return Gamehandler{}, fmt.Errorf("%s: %w", path, err)
+	}
+	handler.rng = rand.New(rand.NewSource(saved.Seed))
+	handler.firstClick = saved.FirstClick
+	handler.setTopology(topologyNamed(saved.Grid))
+
+	// Each mine showing took a life so the game may already be lost, otherwise it may already be won
+	handler.livesFromBoard()
+	handler.checkWin()
+	handler.start = encodeBoard(&handler)
+	return handler, nil
+}
+
+// Turns the board into one string per row (see the letters at the top of the file)
+// Inputs: gameHandler object
+// Outputs: Row strings
+func encodeBoard(handler *Gamehandler) []string {
+	rows := make([]string, handler.rows)
+	for r := 0; r < handler.rows; r++ {
+		row := strings.Builder{}
+		for c := 0; c < handler.cols; c++ {
+			sq := handler.board[r][c]
+			letters := ".ofq" // covered, uncovered, flagged, question mark (in SquareState order)
+			if sq.isBomb() {
+				letters = "*xFQ"
+			}
+			row.WriteByte(letters[sq.state])
+			if handler.cellMines > 1 {
+				fmt.Fprintf(&row, "%d%d", sq.bombs, sq.flags)
+			}
+		}
+		rows[r] = row.String()
+	}
+	return rows
+}
+
+// Sets the bombs and cell states from the row strings, and counts the mines
+// Inputs: gameHandler object with an empty board of the right size (and its mines per cell) and the row strings
+// Outputs: Error if a row has the wrong length, an unknown letter or counts that don't fit the letter
+func decodeBoard(handler *Gamehandler, rows []string) error {
+	if len(rows) != handler.rows {
+		return fmt.Errorf("expected %d board rows, found %d", handler.rows, len(rows))
+	}
+	width := 1 // Characters per cell, the letter and in the multi-mine game its two counts
+	if handler.cellMines > 1 {
+		width = 3
+	}
+	handler.totalMines = 0
+	for r, text := range rows {
+		if len(text) != handler.cols*width {
+			return fmt.Errorf("board row %d should have %d cells, found %d", r+1, handler.cols, len(text)/width)
+		}
+		for c := 0; c < handler.cols; c++ {
+			sq := &handler.board[r][c]
+			switch text[c*width] {
+			case '.':
+				sq.state = Covered
+			case 'o':
+				sq.state = Uncovered
+			case 'f':
+				sq.state = Flagged
+			case 'q':
+				sq.state = Questioned
+			case '*':
+				sq.state, sq.bombs = Covered, 1
+			case 'x':
+				sq.state, sq.bombs = Uncovered, 1
+			case 'F':
+				sq.state, sq.bombs = Flagged, 1
+			case 'Q':
+				sq.state, sq.bombs = Questioned, 1
+			default:
+				return fmt.Errorf("unknown cell %q in board row %d", text[c*width], r+1)
+			}
+			if sq.state == Flagged {
+				sq.flags = 1
+			}
+			if width == 3 {
+				bombs, flags := int(text[c*3+1]-'0'), int(text[c*3+2]-'0')
+				if bombs < 0 || bombs > handler.cellMines || (bombs > 0) != sq.isBomb() ||
+					flags < 0 || flags > handler.cellMines || (flags > 0) != (sq.state == Flagged) {
+					return fmt.Errorf("cell %d of board row %d has counts that don't fit its letter", c+1, r+1)
+				}
+				sq.bombs, sq.flags = bombs, flags
+			}
+			handler.totalMines += sq.bombs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the score modes. In a Time Attack game a countdown (config.TimeLimits) starts with the first reveal, in
+a Move Budget game every reveal and chord uses up one move of a fixed budget (config.MoveLimits). The game ends when
+the time or the moves run out and the score is the number of safe cells uncovered
+
+Functions:
+- setTimeLimit/setMoveLimit: Make a game a Time Attack or Move Budget game
+
+- scored: Whether a game is played for a score
+
+- usedMove: Starts the countdown on the first reveal and uses up a move of the budget
+
+- checkClock: Ends a Time Attack game whose time ran out
+
+- endByLimit: Ends the game because the time or the moves ran out
+
+- score: The safe cells uncovered
+
+- timeLeft: What is left of the countdown
+
+- scoreText: The time or moves left and the score for the headers and status lines
+
+- limitTitle: A mode's limit in words for the titles
+
+Inputs:
+- Game handler, the limit picked on the setup screen
+
+Outputs:
+- Whether the game is over, the score
+*/
+
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// How often the front-ends look at the countdown of a Time Attack game
+const clockTick = 200 * time.Millisecond
+
+// Makes a game a Time Attack game, the countdown starts with the first reveal
+// Inputs: gameHandler object and the countdown in seconds (0 for no countdown)
+// Outputs: None, changes the handler
+func (handler *Gamehandler) setTimeLimit(limit int) {
+	handler.timeLimit = max(limit, 0)
+	handler.deadline = time.Time{}
+}
+
+// Makes a game a Move Budget game
+// Inputs: gameHandler object and the reveals and chords allowed (0 for no budget)
+// Outputs: None, changes the handler
+func (handler *Gamehandler) setMoveLimit(moves int) {
+	handler.moveLimit = max(moves, 0)
+	handler.movesUsed = 0
+}
+
+// Tells whether a game is played for a score (Time Attack, Move Budget or infinite)
+// Inputs: gameHandler object
+// Outputs: Bool
+func (handler *Gamehandler) scored() bool {
+	return handler.timeLimit > 0 || handler.moveLimit > 0 || handler.infinite != nil
+}
+
+// Counts a reveal or chord that uncovered something: the first one starts the countdown of a Time Attack game and in
+// a Move Budget game each one uses up a move, the game ends when the last one is used (unless it already ended)
+// Inputs: gameHandler object
+// Outputs: None, changes the handler
+func (handler *Gamehandler) usedMove() {
+	if handler.timeLimit > 0 && handler.deadline.IsZero() {
+		handler.deadline = time.Now().Add(time.Duration(handler.timeLimit) * time.Second)
+	}
+	if handler.moveLimit == 0 {
+		return
+	}
+	handler.movesUsed++
+	if handler.movesUsed >= handler.moveLimit && !handler.gameOver {
+		handler.endByLimit()
+	}
+}
+
+// Ends a Time Attack game once its countdown has run out, the end is recorded as a move for the replay. The
+// front-ends call this before every player move and on a timer while the game is shown
+// Inputs: gameHandler object
+// Outputs: True if the game ended just now
+func (handler *Gamehandler) checkClock() bool {
+	if handler.gameOver || handler.deadline.IsZero() || time.Now().Before(handler.deadline) {
+		return false
+	}
+	handler.record(MoveTimeUp, 0, 0)
+	handler.endByLimit()
+	return true
+}
+
+// Ends the game because the time or the moves ran out, the board stays as it is and the score is what was cleared
+// Inputs: gameHandler object
+// Outputs: None, changes the handler
+func (handler *Gamehandler) endByLimit() {
+	handler.gameOver = true
+	handler.win = false
+	handler.limitReached = true
+}
+
+// Gives the score, the safe cells uncovered so far
+// Inputs: gameHandler object
+// Outputs: Number of cells
+func (handler *Gamehandler) score() int {
+	if handler.infinite != nil {
+		return handler.infinite.cleared // Most of the cleared cells are out of view
+	}
+	cleared := 0
+	for r := range handler.board {
+		for c := range handler.board[r] {
+			if sq := handler.board[r][c]; sq.state == Uncovered && !sq.isBomb() {
+				cleared++
+			}
+		}
+	}
+	return cleared
+}
+
+// Gives what is left of the countdown, all of it before the first reveal
+// Inputs: gameHandler object
+// Outputs: Time left, never below 0
+func (handler *Gamehandler) timeLeft() time.Duration {
+	if handler.deadline.IsZero() {
+		return time.Duration(handler.timeLimit) * time.Second
+	}
+	return max(time.Until(handler.deadline), 0)
+}
+
+// Gives the time or moves left and the score for the headers and status lines, once the game is over only the score
+// Inputs: gameHandler object
+// Outputs: Text such as "Time left: 1:05  Score: 14", empty for a game that isn't played for a score
+func scoreText(handler *Gamehandler) string {
+	score := fmt.Sprintf("Score: %d", handler.score())
+	switch {
+	case !handler.scored():
+		return ""
+	case handler.gameOver || handler.infinite != nil:
+		return score
+	case handler.timeLimit > 0:
+		left := handler.timeLeft().Round(time.Second)
+		return fmt.Sprintf("Time left: %d:%02d  %s", int(left.Minutes()), int(left.Seconds())%60, score)
+	}
+	return fmt.Sprintf("Moves left: %d of %d  %s", handler.moveLimit-handler.movesUsed, handler.moveLimit, score)
+}
+
+// Gives the limit of a score mode in words, e.g. "2:00" or "50 moves"
+// Inputs: Mode ("Timed" or "Moves") and option (the limit in seconds or moves)
+// Outputs: The limit, or the option as it is if it isn't a number
+func limitTitle(mode string, option string) string {
+	n, err := strconv.Atoi(option)
+	switch {
+	case err != nil:
+		return option
+	case mode == "Timed":
+		return fmt.Sprintf("%d:%02d", n/60, n%60)
+	}
+	return fmt.Sprintf("%d moves", n)
+}
@@ -0,0 +1,372 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the Settings screen opened from the title screen. Changes are applied and saved to the settings file
+(config/settings.go) straight away so there is no separate save button, a value that is not valid is shown as an error
+and not saved. The settings are split over three tabs:
+  - General: default board size and mine count, AI delay, the first click policy, question marks and the player name
+    for the daily challenge leaderboard
+  - Look: theme, colour-blind palette, AI markers and speech, shown on a small read-only board
+  - Keys: the keys for each action on the game board
+
+Functions:
+- showSettings: Shows the settings screen in the window
+
+- generalSettings/lookSettings/keySettings: Build the three tabs
+
+- numberEntry: Entry for a whole number setting that checks the value before using it
+
+- themePreview: Makes the small example board for the theme picker
+
+- saveSettings: Saves the settings file, showing the error on the screen if it could not be written
+
+Inputs:
+- Setting choices from the user
+
+Outputs:
+- Updated and saved settings
+*/
+
+package components
+
+import (
+	"fmt"
+	"minesweeper/config"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Shows the settings screen
+// Inputs: the fyne window itself
+// Outputs: None, replaces the window content
+func showSettings(win fyne.Window) {
+	errLabel := widget.NewLabel("")
+	errLabel.Wrapping = fyne.TextWrapWord
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("General", generalSettings(errLabel)),
+		container.NewTabItem("Look", lookSettings(errLabel)),
+		container.NewTabItem("Keys", keySettings(errLabel)),
+	)
+
+	backButton := widget.NewButton("Back", func() {
+		LoadSetupInto(win)
+	})
+
+	win.SetContent(container.NewPadded(container.NewBorder(nil, container.NewVBox(errLabel, backButton), nil, nil, tabs)))
+}
+
+// Builds the General tab: board size, mine count, AI delay, first click policy, question marks and player name
+// Inputs: Label for errors
+// Outputs: Tab content
+func generalSettings(errLabel *widget.Label) fyne.CanvasObject {
+	lo, hi := config.MineLimits(config.Current.Rows, config.Current.Cols)
+	minesHint := widget.NewLabel(fmt.Sprintf("%d-%d for this board size", lo, hi))
+
+	minesEntry := numberEntry(errLabel, config.Current.Mines, func(n int) error {
+		lo, hi := config.MineLimits(config.Current.Rows, config.Current.Cols)
+		if n < lo || n > hi {
+			return fmt.Errorf("Mine count must be between %d and %d.", lo, hi)
+		}
+		config.Current.Mines = n
+		config.Current.Difficulty = config.DifficultyCustom
+		return nil
+	})
+
+	// A new board size changes the allowed mine counts, so the mine count is moved into the new range
+	sizeChanged := func() {
+		lo, hi := config.MineLimits(config.Current.Rows, config.Current.Cols)
+		minesHint.SetText(fmt.Sprintf("%d-%d for this board size", lo, hi))
+		config.Current.Mines = min(max(config.Current.Mines, lo), hi)
+		config.Current.Difficulty = config.DifficultyCustom
+		minesEntry.SetText(strconv.Itoa(config.Current.Mines))
+	}
+	checkSize := func(n int) error {
+		if n < config.MinBoardSize || n > config.MaxBoardSize {
+			return fmt.Errorf("Board size must be between %d and %d.", config.MinBoardSize, config.MaxBoardSize)
+		}
+		return nil
+	}
+	colsEntry := numberEntry(errLabel, config.Current.Cols, func(n int) error {
+		if err := checkSize(n); err != nil {
+			return err
+		}
+		config.Current.Cols = n
+		sizeChanged()
+		return nil
+	})
+	rowsEntry := numberEntry(errLabel, config.Current.Rows, func(n int) error {
+		if err := checkSize(n); err != nil {
+			return err
+		}
+		config.Current.Rows = n
+		sizeChanged()
+		return nil
+	})
+
+	delayEntry := numberEntry(errLabel, config.Current.AIDelayMs, func(n int) error {
+		if n < 0 {
+			return fmt.Errorf("AI delay can't be negative.")
+		}
+		config.Current.AIDelayMs = n
+		return nil
+	})
+
+	policies := []string{"Move the mine away", "Always open an area", "Can lose on the first click"}
+	policyValues := []string{config.FirstClickSafe, config.FirstClickOpening, config.FirstClickNone}
+	policySelect := widget.NewSelect(policies, func(label string) {
+		value := policyValues[slices.Index(policies, label)]
+		if value == config.Current.FirstClick {
+			return
+		}
+		config.Current.FirstClick = value
+		saveSettings(errLabel)
+	})
+	policySelect.SetSelected(policies[max(0, slices.Index(policyValues, config.Current.FirstClick))])
+
+	questionCheck := widget.NewCheck("Flagging a flag again turns it into a question mark", func(on bool) {
+		if on == config.Current.QuestionMarks {
+			return
+		}
+		config.Current.QuestionMarks = on
+		saveSettings(errLabel)
+	})
+	questionCheck.SetChecked(config.Current.QuestionMarks)
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(config.Current.PlayerName)
+	nameEntry.OnChanged = func(text string) {
+		if strings.TrimSpace(text) == "" {
+			errLabel.SetText("Player name can't be empty.")
+			return
+		}
+		config.Current.PlayerName = strings.TrimSpace(text)
+		saveSettings(errLabel)
+	}
+
+	return container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Board width", colsEntry),
+			widget.NewFormItem("Board height", rowsEntry),
+			widget.NewFormItem("Mines", minesEntry),
+			widget.NewFormItem("", minesHint),
+			widget.NewFormItem("AI delay (ms)", delayEntry),
+			widget.NewFormItem("First click", policySelect),
+			widget.NewFormItem("Player name", nameEntry),
+		),
+		questionCheck,
+	)
+}
+
+// Builds the Look tab: theme, palette, AI markers and speech with a preview board
+// Inputs: Label for errors
+// Outputs: Tab content
+func lookSettings(errLabel *widget.Label) fyne.CanvasObject {
+	preview := container.NewCenter(themePreview())
+
+	// Redraws the preview with the new look and saves the change
+	changed := func() {
+		fyne.CurrentApp().Settings().SetTheme(AppTheme())
+		preview.Objects = []fyne.CanvasObject{themePreview()}
+		preview.Refresh()
+		saveSettings(errLabel)
+	}
+
+	themeSelect := widget.NewSelect(ThemeNames(), func(name string) {
+		if name == config.Current.Theme {
+			return
+		}
+		config.Current.Theme = name
+		changed()
+	})
+	themeSelect.SetSelected(themeNamed(config.Current.Theme).Name)
+
+	paletteSelect := widget.NewSelect(PaletteNames(), func(name string) {
+		if name == config.Current.Palette {
+			return
+		}
+		config.Current.Palette = name
+		changed()
+	})
+	if config.Current.Palette == "" {
+		config.Current.Palette = PaletteTheme
+	}
+	paletteSelect.SetSelected(config.Current.Palette)
+
+	markersCheck := widget.NewCheck("Mark cells the AI revealed with a corner shape", func(on bool) {
+		if on == config.Current.AIMarkers {
+			return
+		}
+		config.Current.AIMarkers = on
+		changed()
+	})
+	markersCheck.SetChecked(config.Current.AIMarkers)
+
+	speakCheck := widget.NewCheck("Read moves and the result out loud", func(on bool) {
+		if on == config.Current.Speak {
+			return
+		}
+		config.Current.Speak = on
+		saveSettings(errLabel)
+		speak("Speech is on")
+	})
+	speakCheck.SetChecked(config.Current.Speak)
+
+	form := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Theme", themeSelect),
+			widget.NewFormItem("Colours", paletteSelect),
+		),
+		markersCheck,
+		speakCheck,
+	)
+	return container.NewBorder(form, nil, nil, nil, preview)
+}
+
+// Builds the Keys tab, each action gets an entry with its keys separated by commas (Fyne key names such as Up, Space,
+// Return, F2, W or /). Keys that are already used by another action are refused
+// Inputs: Label for errors
+// Outputs: Tab content
+func keySettings(errLabel *widget.Label) fyne.CanvasObject {
+	defaults := DefaultKeymap()
+	keys := currentKeymap()
+	form := widget.NewForm()
+	entries := map[string]*widget.Entry{}
+
+	for _, a := range keyActions {
+		action := a.action
+		entry := widget.NewEntry()
+		entry.SetText(joinKeys(keys[action]))
+		entry.OnChanged = func(text string) {
+			names := []string{}
+			for _, name := range strings.Split(text, ",") {
+				name = strings.TrimSpace(name)
+				if len(name) == 1 {
+					name = strings.ToUpper(name) // Letter keys are named in capitals
+				}
+				if name != "" {
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				errLabel.SetText(a.label + " needs at least one key.")
+				return
+			}
+			// A key can only do one thing, it has to be taken off the other action first
+			keys := currentKeymap()
+			for _, other := range keyActions {
+				for _, key := range keys[other.action] {
+					if other.action != action && slices.Contains(names, string(key)) {
+						errLabel.SetText(fmt.Sprintf("%s is already a key for %s.", key, other.label))
+						return
+					}
+				}
+			}
+			if config.Current.Keys == nil {
+				config.Current.Keys = map[string][]string{}
+			}
+			if joinKeys(defaults[action]) == strings.Join(names, ", ") {
+				delete(config.Current.Keys, action) // Back to the default, so later changes to the defaults still apply
+			} else {
+				config.Current.Keys[action] = names
+			}
+			saveSettings(errLabel)
+		}
+		entries[action] = entry
+		form.Append(a.label, entry)
+	}
+
+	resetButton := widget.NewButton("Reset to default keys", func() {
+		config.Current.Keys = nil
+		for action, entry := range entries {
+			entry.SetText(joinKeys(defaults[action]))
+		}
+		saveSettings(errLabel)
+	})
+
+	return container.NewBorder(nil, resetButton, nil, nil, container.NewVScroll(form))
+}
+
+// Joins key names with commas for the Keys tab
+func joinKeys(keys []fyne.KeyName) string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = string(key)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Makes an entry for a whole number setting, every change is checked and only saved when it is valid
+// Inputs: Label for errors, starting value and a function that checks and stores a new value
+// Outputs: The entry
+func numberEntry(errLabel *widget.Label, value int, set func(int) error) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.SetText(strconv.Itoa(value))
+	entry.OnChanged = func(text string) {
+		n, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil {
+			errLabel.SetText("Please enter a valid integer.")
+			return
+		}
+		if err := set(n); err != nil {
+			errLabel.SetText(err.Error())
+			return
+		}
+		saveSettings(errLabel)
+	}
+	return entry
+}
+
+// Makes a small board with a few cells opened (some by the AI), one flag and one mine showing, drawn in the current theme
+// Inputs: None
+// Outputs: Read-only board widget
+func themePreview() *BoardWidget {
+	h := NewSizedGameHandler(config.BoardSize, config.BoardSize, config.MinMines, 1)
+	h.firstClickPolicy = config.FirstClickSafe
+	h.Click(config.BoardSize/2, config.BoardSize/2)
+	opened := false
+	bombsSeen := 0
+	for r := range h.board {
+		for c := range h.board[r] {
+			if !opened && !h.board[r][c].isBomb() && h.board[r][c].numValue == 0 {
+				h.Click(r, c) // Opens an empty patch so some numbers show
+				opened = true
+			}
+			if r >= config.BoardSize/2 && h.board[r][c].state == Uncovered {
+				h.board[r][c].markedByAI = true // Shows the AI colour/marker on the bottom half
+			}
+			if !h.board[r][c].isBomb() || h.board[r][c].state != Covered {
+				continue
+			}
+			bombsSeen++
+			if bombsSeen == 1 {
+				h.ToggleFlag(r, c)
+			} else if bombsSeen == 2 {
+				h.board[r][c].state = Uncovered // Only so the mine icon shows, this game is never played
+			}
+		}
+	}
+	b := NewBoardWidget(&h)
+	b.ReadOnly = true
+	return b
+}
+
+// Saves the settings file
+// Inputs: Label to show an error on
+// Outputs: None, the label is cleared when saving worked
+func saveSettings(errLabel *widget.Label) {
+	if err := config.SaveSettings(); err != nil {
+		errLabel.SetText("Could not save settings: " + err.Error())
+		return
+	}
+	errLabel.SetText("")
+}
@@ -5,18 +5,45 @@ Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
 Creation Date: 9/21/2025
 
 Description:
-- This file implements the setup screen for the game, where the user is able to choose the number of
-mines that they want in the game and validates that number if it's within the range. Afterwards it swaps the current view for the minesweeper view allowing the game to start
+- This file implements the setup screen for the game, where the user picks a difficulty preset (Beginner 9x9/10,
+Intermediate 16x16/40, Expert 30x16/99) or a Custom board with sliders for the width, height and mine density (percent
+of the cells). The mine count the density works out to is shown and checked against the board size and the cells the
+first click keeps clear. The grid and neighbor rule (square or hexagonal cells, a wrap-around board, or knight's move,
+radius 2 and cross neighbors, see topology.go) and how many mines one cell can hold (multimine.go) are picked above the
+difficulties. The choices are saved in the settings so they are picked again next time.
+Afterwards it swaps the current view for the minesweeper view allowing the game to start
 
 Functions:
-- LoadSetupInfo: This loads the initial setup screen and asks the user for the number of mines.
-Upon a valid entry, it'll create a new game and replaces the window with the game board.
+- LoadSetupInfo: This loads the title screen with the Play, Open, Replays (replay-viewer.go), Settings
+(settings-screen.go) and Exit buttons
+
+- openBoard: Asks for a save file, board layout (layout.go) or RAWVF file and plays it
+
+- gameSelect: The game mode screen, the Infinite button starts an infinite game (infinite.go) straight away
+
+- showLimitSelect: The time limit or move budget screen of the score modes (scoremode.go)
+
+- showHotseatSetup: The player names screen of the hot-seat mode (hotseat.go)
+
+- showMineSetup: The neighbor rule (grid), mines per cell, lives and difficulty screen, a preset button starts the game straight away, Custom opens the sliders
+
+- customSetup: Builds the Custom sliders (width, height and mine density) and their Start button
+
+- densityMineCount: Works out the mine count for a density and checks it fits the board
+
+- startGame: Saves the chosen board in the settings and replaces the window with the game board
+
+- parseMineCount: Validates the typed mine count (shared with the terminal front-end)
+
+- newModeHandler: Creates a game handler with the selected mode (Single/AI/Solve/Timed/Moves/Hotseat) applied to it
+
+- applyMode: Turns on the AI opponent, the solver, a score mode's limit or the hot-seat players for a game handler
 
 Inputs:
-- Mine count from the user
+- Difficulty or custom board from the user
 
 Outputs:
-- Either the Minesweeper board, or the error message depending on if the user entered a mine number in range or not. (Error message will re-prompt for input)
+- The Minesweeper board
 
 */
 
@@ -26,11 +53,15 @@ import (
 	"fmt"
 	"image/color"
 	"minesweeper/config"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -50,6 +81,21 @@ func LoadSetupInto(win fyne.Window) {
 		gameSelect(win)
 	})
 
+	//Open Button
+	openButton := widget.NewButton("Open Board or Save", func() {
+		openBoard(win)
+	})
+
+	//Replays Button
+	replaysButton := widget.NewButton("Replays", func() {
+		showReplayList(win)
+	})
+
+	//Settings Button
+	settingsButton := widget.NewButton("Settings", func() {
+		showSettings(win)
+	})
+
 	//Exit Button
 	exitButton := widget.NewButton("Exit", func() {
 		win.Close()
@@ -58,12 +104,38 @@ func LoadSetupInto(win fyne.Window) {
 	from := container.NewVBox(
 		titlePlace,
 		playButton,
+		openButton,
+		replaysButton,
+		settingsButton,
 		exitButton,
 	)
 
 	win.SetContent(container.NewPadded(from))
 }
 
+// Asks for a file and plays the game in it, a save file continues and a layout or RAWVF file starts on that board
+// Inputs: the fyne window itself
+// Outputs: None, shows the game or an error dialog saying what is wrong with the file
+func openBoard(win fyne.Window) {
+	dialog.ShowFileOpen(func(file fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if file == nil {
+			return // Cancelled
+		}
+		path := file.URI().Path()
+		file.Close()
+		h, err := loadBoardFile(path)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		ShowGame(win, &h)
+	}, win)
+}
+
 // Game Select Screen
 func gameSelect(win fyne.Window) {
 	modelLabel := widget.NewLabel("Choose Game Mode:")
@@ -71,18 +143,51 @@ func gameSelect(win fyne.Window) {
 	singleButton := widget.NewButton("Single Player", func() {
 		showMineSetup(win, "Single", "Play")
 	})
+	timedButton := widget.NewButton("Time Attack", func() {
+		showLimitSelect(win, "Timed")
+	})
+	movesButton := widget.NewButton("Move Budget", func() {
+		showLimitSelect(win, "Moves")
+	})
+	// The infinite board has no size or mine count to pick so it starts straight away, its best score is on the button
+	infiniteText := "Infinite"
+	if stats, err := LoadStats(); err != nil {
+		fmt.Println("could not read the stats:", err)
+	} else if best := stats.Games[GamesInfinite].BestScore; best > 0 {
+		infiniteText += fmt.Sprintf(" (best score %d)", best)
+	}
+	infiniteButton := widget.NewButton(infiniteText, func() {
+		h := newInfiniteHandler(time.Now().UnixNano())
+		ShowGame(win, &h)
+	})
+	hotseatButton := widget.NewButton("Hot Seat (2 Players)", func() {
+		showHotseatSetup(win)
+	})
 	aiButton := widget.NewButton("AI 1v1 Mode", func() {
 		showAImode(win, "comp")
 	})
 	solverButton := widget.NewButton("AI Solver Mode", func() {
 		showAImode(win, "Solver")
 	})
+	dailyButton := widget.NewButton("Daily Challenge", func() {
+		showDaily(win)
+	})
+	puzzleButton := widget.NewButton("Puzzles", func() {
+		pack := DefaultPuzzlePack()
+		showPuzzles(win, &pack)
+	})
 
 	from := container.NewVBox(
 		modelLabel,
 		singleButton,
+		timedButton,
+		movesButton,
+		infiniteButton,
 		aiButton,
 		solverButton,
+		hotseatButton,
+		dailyButton,
+		puzzleButton,
 	)
 	win.SetContent(container.NewPadded(from))
 }
@@ -115,54 +220,303 @@ func showAImode(win fyne.Window, mode string) {
 	win.SetContent(container.NewPadded(from))
 }
 
-// Mine Setup Screen
-func showMineSetup(win fyne.Window, mode string, option string) {
-	entry := widget.NewEntry()
-	entry.SetPlaceHolder(fmt.Sprintf("Enter mine count (%d-%d)", config.MinMines, config.MaxMines))
-	entry.SetText(fmt.Sprintf("%d", config.MinMines))
-	errLabel := widget.NewLabel("")
+// Score Mode Screen, picks the countdown of a Time Attack game or the budget of a Move Budget game (scoremode.go), each
+// with the best score so far
+func showLimitSelect(win fyne.Window, mode string) {
+	stats, err := LoadStats()
+	if err != nil {
+		fmt.Println("could not read the stats:", err)
+	}
+	label := widget.NewLabel("Clear as many cells as you can before the time runs out:")
+	limits := config.TimeLimits
+	if mode == "Moves" {
+		label.SetText("Clear as many cells as you can, every reveal and chord uses a move:")
+		limits = config.MoveLimits
+	}
+	from := container.NewVBox(label)
+	for _, limit := range limits {
+		option := strconv.Itoa(limit)
+		text := limitTitle(mode, option)
+		if best := stats.Games[scoreKind(mode, option)].BestScore; best > 0 {
+			text += fmt.Sprintf(" (best score %d)", best)
+		}
+		from.Add(widget.NewButton(text, func() {
+			showMineSetup(win, mode, option)
+		}))
+	}
+	win.SetContent(container.NewPadded(from))
+}
 
-	// Create the "Setup window start"
-	start := widget.NewButton("Start Game", func() {
-		// Checks if entered value is int and not something random
-		n, err := strconv.Atoi(entry.Text)
+// Hot-seat Screen, the two players type their names (the last ones used are filled in) next to the colour their cells
+// will have, then the board is picked on the mine setup screen
+func showHotseatSetup(win fyne.Window) {
+	from := container.NewVBox(widget.NewLabel("Two players take turns on one board, who is playing?"))
+	errLabel := widget.NewLabel("")
+	errLabel.Hide()
+	var entries [2]*widget.Entry
+	next := widget.NewButton("Next", func() {
+		names, err := playerNames(entries[0].Text, entries[1].Text)
 		if err != nil {
-			errLabel.SetText("Please enter a valid integer.")
 			return
 		}
-		// Bound checks
-		maxAllowed := config.BoardSize*config.BoardSize - 1
-		if n < config.MinMines || n > config.MaxMines {
-			errLabel.SetText(fmt.Sprintf("Mine count must be between %d and %d.", config.MinMines, config.MaxMines))
-			return
+		config.Current.HotseatNames = names[:]
+		if err := config.SaveSettings(); err != nil {
+			fmt.Println("could not save settings:", err)
 		}
-		if n > maxAllowed {
-			errLabel.SetText("Too many mines for this board size.")
-			return
-		}
-		h := NewGameHandler(n)
-		//Zhang: Apply selected mode
-		fmt.Print("Selected mode: ", mode, " with option: ", option, "\n")
-		if mode == "AI" {
-			h.setAIEnabled(true)
-			h.aiDifficulty = option
-		} else if mode == "Solve" {
-			fmt.Println("Single Player - Solve mode")
-			h.setSolverEnabled(true)
-			h.aiDifficulty = option
+		showMineSetup(win, "Hotseat", hotseatOption(names))
+	})
+
+	// Only allows going on once both names are fine
+	check := func(string) {
+		if _, err := playerNames(entries[0].Text, entries[1].Text); err != nil {
+			errLabel.SetText(err.Error())
+			errLabel.Show()
+			next.Disable()
+		} else {
+			errLabel.Hide()
+			next.Enable()
 		}
-		board := GetBoard(&h)
-		ui := SetupGameGraphics(board, &h)
-		win.SetContent(ui)
+	}
+	for i := range entries {
+		entries[i] = widget.NewEntry()
+		entries[i].SetText(config.Current.HotseatNames[i])
+		entries[i].SetPlaceHolder(fmt.Sprintf("Player %d", i+1))
+		swatch := canvas.NewRectangle(playerColours[i])
+		swatch.SetMinSize(fyne.NewSize(16, 16))
+		from.Add(container.NewBorder(nil, nil, container.NewCenter(swatch), nil, entries[i]))
+	}
+	for _, entry := range entries {
+		entry.OnChanged = check
+	}
+	check("")
+	back := widget.NewButton("Back", func() {
+		gameSelect(win)
+	})
+	from.Add(errLabel)
+	from.Add(next)
+	from.Add(back)
+	win.SetContent(container.NewPadded(from))
+}
+
+// Mine Setup Screen, the grid from the settings is picked and the last difficulty picked is highlighted (and Custom
+// starts open if it was picked)
+func showMineSetup(win fyne.Window, mode string, option string) {
+	custom, recheck := customSetup(win, mode, option)
+	custom.Hide()
 
+	// Labels in config.Grids order
+	grids := []string{
+		"Square (8 neighbors)",
+		"Hexagonal (6 neighbors)",
+		"Wrap-around (edges join, always 8 neighbors)",
+		"Knight's move (8 cells a knight's move away)",
+		"Radius 2 (24 cells, two steps in every direction)",
+		"Cross (4 neighbors, no diagonals)",
+	}
+	gridSelect := widget.NewSelect(grids, func(label string) {
+		config.Current.Grid = config.Grids[slices.Index(grids, label)]
+		recheck() // The first click may keep a different number of cells clear now
 	})
+	gridSelect.SetSelected(grids[max(0, slices.Index(config.Grids, config.Current.Grid))])
+
+	// Labels for 1 to config.MaxCellMines mines per cell
+	cellMines := []string{"1 (classic)"}
+	for n := 2; n <= config.MaxCellMines; n++ {
+		cellMines = append(cellMines, fmt.Sprintf("Up to %d (a cell needs a flag per mine)", n))
+	}
+	cellMinesSelect := widget.NewSelect(cellMines, func(label string) {
+		config.Current.CellMines = slices.Index(cellMines, label) + 1
+	})
+	cellMinesSelect.SetSelected(cellMines[config.Current.CellMines-1])
+
+	// Labels for 1 to config.MaxLives lives
+	lives := []string{"1 (classic, the first mine ends the game)"}
+	for n := 2; n <= config.MaxLives; n++ {
+		lives = append(lives, fmt.Sprintf("%d (a mine costs a life)", n))
+	}
+	livesSelect := widget.NewSelect(lives, func(label string) {
+		config.Current.Lives = slices.Index(lives, label) + 1
+	})
+	livesSelect.SetSelected(lives[config.Current.Lives-1])
 
-	// Creates a vertical box and shows it to display the setup to the user
 	form := container.NewVBox(
-		widget.NewLabel(fmt.Sprintf("Select number of mines (%d-%d):", config.MinMines, config.MaxMines)),
-		entry,
-		start,
-		errLabel,
+		container.NewBorder(nil, nil, widget.NewLabel("Neighbors:"), nil, gridSelect),
+		container.NewBorder(nil, nil, widget.NewLabel("Mines per cell:"), nil, cellMinesSelect),
+		container.NewBorder(nil, nil, widget.NewLabel("Lives:"), nil, livesSelect),
+		widget.NewLabel("Choose a difficulty:"),
 	)
-	win.SetContent(container.NewPadded(form))
+	for _, p := range config.Presets {
+		button := widget.NewButton(fmt.Sprintf("%s (%dx%d, %d mines)", p.Label, p.Cols, p.Rows, p.Mines), func() {
+			config.Current.Difficulty = p.Name
+			startGame(win, p.Rows, p.Cols, p.Mines, mode, option)
+		})
+		if p.Name == config.Current.Difficulty {
+			button.Importance = widget.HighImportance
+		}
+		form.Add(button)
+	}
+
+	customButton := widget.NewButton("Custom", func() {
+		custom.Show()
+	})
+	if config.Current.Difficulty == config.DifficultyCustom {
+		customButton.Importance = widget.HighImportance
+		custom.Show()
+	}
+	form.Add(customButton)
+	form.Add(custom)
+
+	win.SetContent(container.NewPadded(container.NewVScroll(form)))
+}
+
+// Builds the Custom board sliders, starting from the board in the settings. Mines are picked as a density (percent of
+// the cells), the count it works out to is shown and checked against the board size and the first click's safe zone
+// Inputs: the fyne window, mode and option for the game
+// Outputs: The sliders with a Start button under them, and a function that checks the mine count again (for when the
+// grid changes)
+func customSetup(win fyne.Window, mode string, option string) (*fyne.Container, func()) {
+	rows, cols := config.Current.Rows, config.Current.Cols
+	percent := config.MineDensity(rows, cols, config.Current.Mines)
+
+	widthLabel := widget.NewLabel("")
+	heightLabel := widget.NewLabel("")
+	densityLabel := widget.NewLabel("")
+	errLabel := widget.NewLabel("")
+	widthSlider := widget.NewSlider(config.MinBoardSize, config.MaxBoardSize)
+	heightSlider := widget.NewSlider(config.MinBoardSize, config.MaxBoardSize)
+	densitySlider := widget.NewSlider(1, maxDensity)
+
+	start := widget.NewButton("Start Custom Game", func() {
+		mines, err := densityMineCount(rows, cols, percent)
+		if err != nil {
+			return
+		}
+		config.Current.Difficulty = config.DifficultyCustom
+		startGame(win, rows, cols, mines, mode, option)
+	})
+
+	// Shows the values and the mine count, only allowing a start when that count fits the board
+	update := func() {
+		widthLabel.SetText(fmt.Sprintf("Width: %d", cols))
+		heightLabel.SetText(fmt.Sprintf("Height: %d", rows))
+		mines, err := densityMineCount(rows, cols, percent)
+		densityLabel.SetText(fmt.Sprintf("Mine density: %d%% (%d mines)", percent, mines))
+		if err != nil {
+			errLabel.SetText(err.Error())
+			errLabel.Show()
+			start.Disable()
+		} else {
+			errLabel.Hide()
+			start.Enable()
+		}
+	}
+
+	widthSlider.SetValue(float64(cols))
+	heightSlider.SetValue(float64(rows))
+	densitySlider.SetValue(float64(percent))
+	widthSlider.OnChanged = func(v float64) {
+		cols = int(v)
+		update()
+	}
+	heightSlider.OnChanged = func(v float64) {
+		rows = int(v)
+		update()
+	}
+	densitySlider.OnChanged = func(v float64) {
+		percent = int(v)
+		update()
+	}
+	update()
+
+	return container.NewVBox(
+		widthLabel, widthSlider,
+		heightLabel, heightSlider,
+		densityLabel, densitySlider,
+		errLabel,
+		start,
+	), update
+}
+
+// Highest density the Custom slider goes to, denser boards can't be solved without guessing anyway
+const maxDensity = 50
+
+// Works out the mine count for a density and checks it against the board area and the first click's safe zone
+// Inputs: Board rows and columns, density in percent
+// Outputs: The mine count, and an error meant to be shown to the user if it doesn't fit the board
+func densityMineCount(rows int, cols int, percent int) (int, error) {
+	mines := config.DensityMines(rows, cols, percent)
+	lo, hi := config.MineLimits(rows, cols)
+	safe := config.SafeZone(config.Current.FirstClick, topologyNamed(config.Current.Grid).MaxNeighbors())
+	if mines < lo {
+		return mines, fmt.Errorf("Too few mines, a %dx%d board needs at least %d (%d%%).", cols, rows, lo, config.MineDensity(rows, cols, lo))
+	}
+	if mines > hi {
+		return mines, fmt.Errorf("Too many mines, a %dx%d board fits at most %d (%d%%).", cols, rows, hi, config.MineDensity(rows, cols, hi))
+	}
+	if mines > rows*cols-safe {
+		return mines, fmt.Errorf("Too many mines, the first click keeps %d cells clear.", safe)
+	}
+	return mines, nil
+}
+
+// Saves the chosen board as the new default and starts the game on it
+// Inputs: the fyne window, board rows/cols/mines, mode and option for the game
+// Outputs: None, replaces the window content with the game
+func startGame(win fyne.Window, rows int, cols int, mines int, mode string, option string) {
+	config.Current.Rows, config.Current.Cols, config.Current.Mines = rows, cols, mines
+	if err := config.SaveSettings(); err != nil {
+		fmt.Println("could not save settings:", err)
+	}
+
+	//Zhang: Apply selected mode
+	fmt.Print("Selected mode: ", mode, " with option: ", option, "\n")
+	h := newModeHandler(mines, mode, option)
+	ShowGame(win, &h)
+}
+
+// Checks the typed mine count is an integer inside the allowed range for the board size in the settings
+// Inputs: Text the user typed
+// Outputs: The mine count, or an error message meant to be shown to the user
+func parseMineCount(text string) (int, error) {
+	// Checks if entered value is int and not something random
+	n, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("Please enter a valid integer.")
+	}
+	// Bound checks
+	lo, hi := config.MineLimits(config.Current.Rows, config.Current.Cols)
+	if n < lo || n > hi {
+		return 0, fmt.Errorf("Mine count must be between %d and %d.", lo, hi)
+	}
+	return n, nil
+}
+
+// Creates a new game and applies the selected mode to it
+// Inputs: Mine count, mode ("Single", "AI", "Solve", "Timed" or "Moves") and option (AI difficulty, the limit, or
+// "Play" for single player)
+// Outputs: Game handler ready to be handed to a front-end
+func newModeHandler(numMines int, mode string, option string) Gamehandler {
+	h := NewGameHandler(numMines)
+	applyMode(&h, mode, option)
+	return h
+}
+
+// Applies the selected mode to a game handler
+// Inputs: Game handler, mode ("Single", "AI", "Solve", "Timed", "Moves" or "Hotseat") and option (AI difficulty, the
+// time limit in seconds or the move budget, the players' names from hotseatOption, or "Play" for single player)
+// Outputs: None, changes the handler
+func applyMode(h *Gamehandler, mode string, option string) {
+	if mode == "AI" {
+		h.setAIEnabled(true)
+		h.aiDifficulty = option
+	} else if mode == "Solve" {
+		h.setSolverEnabled(true)
+		h.aiDifficulty = option
+	} else if mode == "Hotseat" {
+		h.setHotseat(hotseatNames(option))
+	} else if limit, err := strconv.Atoi(option); err == nil && mode == "Timed" {
+		h.setTimeLimit(limit)
+	} else if err == nil && mode == "Moves" {
+		h.setMoveLimit(limit)
+	}
 }
@@ -0,0 +1,274 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the local stats store, stats.json next to the settings file. Each kind of stat keeps its own section
+so new ones can be added without touching the others: the daily challenge leaderboard (daily.go) and the totals of
+normal single player games with the fastest win on each board, where games with lives (lives.go) are counted on their
+own so extra lives don't count toward the normal wins, and the score modes (scoremode.go) keep their best score for each mode and limit
+
+Functions:
+- statsPath: Where the stats file lives
+
+- LoadStats: Reads the stats file
+
+- SaveStats: Writes the stats file
+
+- scoreKind: The totals key of a score mode and limit
+
+- gameKind: Which totals a game counts toward, if any
+
+- boardKey: The board a best time is kept for
+
+- recordGame: Adds a finished single player game to its totals
+
+- statsSummary: The totals as lines of text
+
+Inputs:
+- The stats file / results to save
+
+Outputs:
+- Stats for the screens to show
+*/
+
+package components
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"minesweeper/config"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stats is everything in the stats file
+type Stats struct {
+	Daily map[string][]DailyResult `json:"daily"` // Daily challenge leaderboard, results keyed by date (2006-01-02)
+	Games map[string]GameStats     `json:"games"` // Totals of single player games keyed by GamesNormal/GamesLives/GamesInfinite/scoreKind
+}
+
+// Keys of Stats.Games
+const (
+	GamesNormal   = "normal"   // The classic game, the first mine ends it
+	GamesLives    = "lives"    // Games started with more than one life
+	GamesInfinite = "infinite" // Infinite games (infinite.go), they are never won so only the best score counts
+)
+
+// GameStats is the totals of one kind of single player game
+type GameStats struct {
+	Played    int              `json:"played"`
+	Won       int              `json:"won"`
+	BestTimes map[string]int64 `json:"best_times,omitempty"` // Fastest win on each board (boardKey), from the first reveal
+	LivesLost int              `json:"lives_lost"`           // Mines hit in games that went on after them (lives games only)
+	BestScore int              `json:"best_score,omitempty"` // Most cells cleared (score modes only)
+}
+
+// DailyResult is one player's finished attempt at a daily challenge
+type DailyResult struct {
+	Player   string    `json:"player"`
+	Won      bool      `json:"won"`
+	TimeMs   int64     `json:"time_ms"` // How long the attempt took, from the first reveal
+	Finished time.Time `json:"finished"`
+}
+
+// Gives the path of the stats file, next to the settings file
+func statsPath() (string, error) {
+	path, err := config.SettingsPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "stats.json"), nil
+}
+
+// Reads the stats file, a missing file gives empty stats
+// Inputs: None
+// Outputs: The stats (every section made even if it is empty), or an error if the file could not be read
+func LoadStats() (Stats, error) {
+	stats := Stats{Daily: map[string][]DailyResult{}, Games: map[string]GameStats{}}
+	path, err := statsPath()
+	if err != nil {
+		return stats, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return Stats{Daily: map[string][]DailyResult{}, Games: map[string]GameStats{}}, fmt.Errorf("%s: %w", path, err)
+	}
+	if stats.Daily == nil {
+		stats.Daily = map[string][]DailyResult{}
+	}
+	if stats.Games == nil {
+		stats.Games = map[string]GameStats{}
+	}
+	return stats, nil
+}
+
+// Writes the stats file, creating the directory if needed
+// Inputs: The stats
+// Outputs: Error if the file could not be written
+func SaveStats(stats Stats) error {
+	path, err := statsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Gives the totals key of a score mode, each limit has its own best score
+// Inputs: Mode ("Timed" or "Moves") and option (the limit)
+// Outputs: Key such as "timed-120" or "moves-50"
+func scoreKind(mode string, option string) string {
+	return strings.ToLower(mode) + "-" + option
+}
+
+// Gives which totals a game counts toward: single player games only, the AI and hot-seat modes, daily challenges (they have their
+// own leaderboard), puzzles and games that didn't start on a new random board (loaded from a save, a layout or a RAWVF
+// file) don't count. A score mode game counts toward its mode and limit even if it has lives
+// Inputs: gameHandler object
+// Outputs: GamesNormal, GamesLives, GamesInfinite or a scoreKind, empty if the game doesn't count
+func gameKind(handler *Gamehandler) string {
+	switch {
+	case handler.aiEnabled || handler.aiSolver || handler.hotseat != nil || handler.daily != "" || handler.puzzle != nil:
+		return ""
+	case handler.start != nil || handler.layout != nil:
+		return ""
+	case handler.infinite != nil:
+		return GamesInfinite
+	case handler.scored():
+		return scoreKind(handlerMode(handler))
+	case handler.startLives > 1:
+		return GamesLives
+	}
+	return GamesNormal
+}
+
+// Gives the board a best time is kept for, so only wins on the same board are compared: the preset or the size and
+// mine count, then the grid and the mines a cell can hold if they aren't the classic ones
+// Inputs: gameHandler object
+// Outputs: Key such as "Beginner" or "12x20 with 30 mines, hex"
+func boardKey(handler *Gamehandler) string {
+	key := fmt.Sprintf("%dx%d with %d mines", handler.cols, handler.rows, handler.totalMines)
+	for _, p := range config.Presets {
+		if p.Rows == handler.rows && p.Cols == handler.cols && p.Mines == handler.totalMines {
+			key = p.Label
+		}
+	}
+	if grid := handler.gridName(); grid != config.GridSquare {
+		key += ", " + grid
+	}
+	if handler.cellMines > 1 {
+		key += fmt.Sprintf(", %d mines per cell", handler.cellMines)
+	}
+	return key
+}
+
+// Adds a finished single player game to its totals, only the first time the game ends (undo can take it back and
+// finish it again)
+// Inputs: gameHandler object of the finished game
+// Outputs: Error if the stats could not be read or written
+func recordGame(handler *Gamehandler) error {
+	kind := gameKind(handler)
+	if kind == "" || !handler.gameOver || handler.recorded {
+		return nil
+	}
+	stats, err := LoadStats()
+	if err != nil {
+		return err
+	}
+	handler.recorded = true
+	totals := stats.Games[kind]
+	totals.Played++
+	if handler.win {
+		totals.Won++
+		if totals.BestTimes == nil {
+			totals.BestTimes = map[string]int64{}
+		}
+		key := boardKey(handler)
+		if best, ok := totals.BestTimes[key]; !ok || handler.playTimeMs() < best {
+			totals.BestTimes[key] = handler.playTimeMs()
+		}
+	}
+	if handler.startLives > 1 {
+		totals.LivesLost += handler.startLives - handler.lives
+	}
+	if handler.scored() {
+		totals.BestScore = max(totals.BestScore, handler.score())
+	}
+	stats.Games[kind] = totals
+	return SaveStats(stats)
+}
+
+// Gives the single player totals as lines of text, e.g. "Normal games: 10 played, 4 won"
+// Inputs: The stats
+// Outputs: One line for each kind of game, normal and lives games first and then infinite games and the score modes
+// that were played, each followed by its best times such as "Best on Beginner: 1:02"
+func statsSummary(stats Stats) []string {
+	// Score modes sorted by mode and then by limit, time attack first
+	scoreKinds := []string{}
+	for kind := range stats.Games {
+		if kind != GamesNormal && kind != GamesLives && kind != GamesInfinite {
+			scoreKinds = append(scoreKinds, kind)
+		}
+	}
+	slices.SortFunc(scoreKinds, func(a, b string) int {
+		modeA, limitA, _ := strings.Cut(a, "-")
+		modeB, limitB, _ := strings.Cut(b, "-")
+		nA, _ := strconv.Atoi(limitA)
+		nB, _ := strconv.Atoi(limitB)
+		return cmp.Or(-cmp.Compare(modeA, modeB), cmp.Compare(nA, nB))
+	})
+	kinds := []string{GamesNormal, GamesLives}
+	if _, ok := stats.Games[GamesInfinite]; ok {
+		kinds = append(kinds, GamesInfinite)
+	}
+	kinds = append(kinds, scoreKinds...)
+
+	lines := []string{}
+	for _, kind := range kinds {
+		totals := stats.Games[kind]
+		name := strings.ToUpper(kind[:1]) + kind[1:]
+		if mode, option, ok := strings.Cut(kind, "-"); ok && mode == "timed" {
+			name = "Time attack " + limitTitle("Timed", option)
+		} else if ok {
+			name = "Move budget " + limitTitle("Moves", option)
+		}
+		line := fmt.Sprintf("%s games: %d played, %d won", name, totals.Played, totals.Won)
+		if kind == GamesInfinite {
+			line = fmt.Sprintf("%s games: %d played", name, totals.Played)
+		}
+		if kind == GamesLives {
+			line += fmt.Sprintf(", %d lives lost", totals.LivesLost)
+		}
+		if totals.BestScore > 0 {
+			line += fmt.Sprintf(", best score %d", totals.BestScore)
+		}
+		lines = append(lines, line)
+		for _, board := range slices.Sorted(maps.Keys(totals.BestTimes)) {
+			lines = append(lines, fmt.Sprintf("    Best on %s: %s", board, formatDuration(totals.BestTimes[board])))
+		}
+	}
+	return lines
+}
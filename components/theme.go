@@ -0,0 +1,288 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file has the board themes. A theme sets the colours of the board (tiles, the uncovered floor, the numbers,
+headers and cursor) and draws the tile, mine and flag icons as small SVG images in those colours so they stay sharp at
+any zoom (with hexagonal tile and floor icons for the hex grid), and mixes the hot-seat players' colours into the floor for
+the cells each of them uncovered. There is a classic theme that looks like the old Windows game (raised grey tiles and the standard number
+colours), a dark theme and a high-contrast theme. The theme is picked on the Settings screen and saved in the settings file
+
+Functions:
+- ThemeNames: Names of the themes in the order the Settings screen lists them
+
+- themeNamed: Finds a theme by name (falls back to classic)
+
+- currentTheme: The theme picked in the settings (with the colour-blind palette from accessibility.go applied)
+
+- AppTheme: Fyne theme for the rest of the window (light or dark to match the board theme)
+
+Inputs:
+- Theme name from the settings
+
+Outputs:
+- Colours and icons for the board widget
+*/
+
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"minesweeper/config"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// BoardTheme is how a board is drawn
+type BoardTheme struct {
+	Name     string
+	Floor    color.Color    // Uncovered cell background
+	Grid     color.Color    // Lines between uncovered cells
+	Header   color.Color    // Row/column labels
+	Cursor   color.Color    // Keyboard cursor outline and status text
+	AI       color.Color    // Numbers the AI revealed
+	Question color.Color    // Question marks drawn on covered cells
+	Numbers  [9]color.Color // Colour of each number, index 0 is unused
+	Players  [2]color.Color // Floor of the cells each hot-seat player uncovered (hotseat.go), made from playerColours
+	Variant  fyne.ThemeVariant
+
+	tile     fyne.Resource // Covered cell
+	hexTile  fyne.Resource // Covered cell on the hex grid
+	hexFloor fyne.Resource // Uncovered cell on the hex grid, the floor colour with the grid colour around it
+	mine     fyne.Resource
+	flag     fyne.Resource
+	mark     fyne.Resource // Corner marker for cells the AI revealed (accessibility.go)
+	blast    fyne.Resource // Burst behind a mine that went off in a lives game (lives.go)
+
+	hexPlayers [2]fyne.Resource // hexFloor in the Players colours
+}
+
+// Colours of the two hot-seat players (blue and orange, apart for every kind of colour blindness), the board tints the
+// floor of their cells with them and the scoreboard shows them next to the names
+var playerColours = [2]color.Color{
+	color.NRGBA{R: 0, G: 114, B: 178, A: 255},
+	color.NRGBA{R: 230, G: 159, B: 0, A: 255},
+}
+
+// Theme names, also what is stored in the settings file
+const (
+	ThemeClassic      = "classic"
+	ThemeDark         = "dark"
+	ThemeHighContrast = "high-contrast"
+)
+
+var boardThemes = map[string]*BoardTheme{
+	ThemeClassic: newBoardTheme(BoardTheme{
+		Name:     ThemeClassic,
+		Floor:    color.NRGBA{R: 192, G: 192, B: 192, A: 255},
+		Grid:     color.NRGBA{R: 128, G: 128, B: 128, A: 255},
+		Header:   color.NRGBA{R: 0, G: 0, B: 0, A: 255},
+		Cursor:   color.NRGBA{R: 255, G: 0, B: 255, A: 255},
+		AI:       color.NRGBA{R: 200, G: 0, B: 200, A: 255},
+		Question: color.NRGBA{R: 0, G: 0, B: 0, A: 255},
+		Numbers: [9]color.Color{nil,
+			color.NRGBA{R: 0, G: 0, B: 255, A: 255},     // 1 blue
+			color.NRGBA{R: 0, G: 128, B: 0, A: 255},     // 2 green
+			color.NRGBA{R: 255, G: 0, B: 0, A: 255},     // 3 red
+			color.NRGBA{R: 0, G: 0, B: 128, A: 255},     // 4 navy
+			color.NRGBA{R: 128, G: 0, B: 0, A: 255},     // 5 maroon
+			color.NRGBA{R: 0, G: 128, B: 128, A: 255},   // 6 teal
+			color.NRGBA{R: 0, G: 0, B: 0, A: 255},       // 7 black
+			color.NRGBA{R: 128, G: 128, B: 128, A: 255}, // 8 grey
+		},
+		Variant: theme.VariantLight,
+	}, raisedTile("#c0c0c0", "#ffffff", "#808080"), raisedHexTile("#c0c0c0", "#ffffff", "#808080"),
+		"#000000", "#000000", "#ff0000"),
+
+	ThemeDark: newBoardTheme(BoardTheme{
+		Name:     ThemeDark,
+		Floor:    color.NRGBA{R: 32, G: 33, B: 36, A: 255},
+		Grid:     color.NRGBA{R: 60, G: 64, B: 67, A: 255},
+		Header:   color.NRGBA{R: 189, G: 193, B: 198, A: 255},
+		Cursor:   color.NRGBA{R: 255, G: 222, B: 33, A: 255},
+		AI:       color.NRGBA{R: 255, G: 255, B: 0, A: 255},
+		Question: color.NRGBA{R: 232, G: 234, B: 237, A: 255},
+		Numbers: [9]color.Color{nil,
+			color.NRGBA{R: 138, G: 180, B: 248, A: 255},
+			color.NRGBA{R: 129, G: 201, B: 149, A: 255},
+			color.NRGBA{R: 242, G: 139, B: 130, A: 255},
+			color.NRGBA{R: 197, G: 138, B: 249, A: 255},
+			color.NRGBA{R: 253, G: 214, B: 99, A: 255},
+			color.NRGBA{R: 120, G: 217, B: 236, A: 255},
+			color.NRGBA{R: 232, G: 234, B: 237, A: 255},
+			color.NRGBA{R: 154, G: 160, B: 166, A: 255},
+		},
+		Variant: theme.VariantDark,
+	}, flatTile("#3c4043", "#1e1e1e"), flatHexTile("#3c4043", "#1e1e1e"), "#e8eaed", "#e8eaed", "#f28b82"),
+
+	ThemeHighContrast: newBoardTheme(BoardTheme{
+		Name:     ThemeHighContrast,
+		Floor:    color.NRGBA{A: 255},
+		Grid:     color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		Header:   color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		Cursor:   color.NRGBA{R: 0, G: 255, B: 255, A: 255},
+		AI:       color.NRGBA{R: 255, G: 0, B: 255, A: 255},
+		Question: color.NRGBA{A: 255}, // Black on the white tiles
+		Numbers: [9]color.Color{nil,
+			color.NRGBA{R: 0, G: 255, B: 255, A: 255},
+			color.NRGBA{R: 0, G: 255, B: 0, A: 255},
+			color.NRGBA{R: 255, G: 255, B: 0, A: 255},
+			color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+			color.NRGBA{R: 255, G: 160, B: 0, A: 255},
+			color.NRGBA{R: 160, G: 255, B: 160, A: 255},
+			color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+			color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+		Variant: theme.VariantDark,
+	}, flatTile("#ffffff", "#000000"), flatHexTile("#ffffff", "#000000"), "#ffffff", "#000000", "#ff0000"),
+}
+
+// Names of the themes in the order the Settings screen lists them
+// Inputs: None
+// Outputs: Theme names
+func ThemeNames() []string {
+	return []string{ThemeClassic, ThemeDark, ThemeHighContrast}
+}
+
+// Finds a theme by name, an unknown name (e.g. from an old settings file) gives the classic theme
+// Inputs: Theme name
+// Outputs: The theme
+func themeNamed(name string) *BoardTheme {
+	if th, ok := boardThemes[name]; ok {
+		return th
+	}
+	return boardThemes[ThemeClassic]
+}
+
+// Gives the theme picked in the settings, recoloured with the colour-blind palette if one is picked
+// Inputs: None
+// Outputs: The theme
+func currentTheme() *BoardTheme {
+	return withPalette(themeNamed(config.Current.Theme), config.Current.Palette)
+}
+
+// Fills in a theme's icons
+// Inputs: Theme colours, the SVGs for covered square and hexagonal tiles, the colour of the mine, of the flag pole and
+// of the flag
+// Outputs: The finished theme
+func newBoardTheme(th BoardTheme, tile string, hexTile string, mineColour string, poleColour string, flagColour string) *BoardTheme {
+	th.tile = fyne.NewStaticResource(th.Name+"-tile.svg", []byte(tile))
+	th.hexTile = fyne.NewStaticResource(th.Name+"-hex-tile.svg", []byte(hexTile))
+	th.hexFloor = hexFloorIcon(th.Name+"-hex-floor.svg", th.Floor, th.Grid)
+	for i, c := range playerColours {
+		th.Players[i] = mixColours(th.Floor, c, 0.45)
+		th.hexPlayers[i] = hexFloorIcon(fmt.Sprintf("%s-hex-floor-%d.svg", th.Name, i+1), th.Players[i], th.Grid)
+	}
+	th.mine = fyne.NewStaticResource(th.Name+"-mine.svg", []byte(fmt.Sprintf(mineSVG, mineColour)))
+	th.flag = fyne.NewStaticResource(th.Name+"-flag.svg", []byte(fmt.Sprintf(flagSVG, poleColour, flagColour)))
+	th.blast = fyne.NewStaticResource(th.Name+"-blast.svg", []byte(fmt.Sprintf(blastSVG, flagColour)))
+	th.mark = aiMarkIcon(th.Name, th.AI)
+	return &th
+}
+
+// An uncovered hexagonal cell, the floor colour with the grid colour around it
+func hexFloorIcon(name string, floor color.Color, grid color.Color) fyne.Resource {
+	return fyne.NewStaticResource(name, []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 18.48">
+<polygon points="%s" fill="%s" stroke="%s" stroke-width="1"/>
+</svg>`, hexPoints, hexColour(floor), hexColour(grid))))
+}
+
+// Mixes two colours, amount is how much of the second one goes in (0 to 1)
+func mixColours(a color.Color, b color.Color, amount float64) color.Color {
+	ca, cb := color.NRGBAModel.Convert(a).(color.NRGBA), color.NRGBAModel.Convert(b).(color.NRGBA)
+	mix := func(x uint8, y uint8) uint8 {
+		return uint8(float64(x)*(1-amount) + float64(y)*amount + 0.5)
+	}
+	return color.NRGBA{R: mix(ca.R, cb.R), G: mix(ca.G, cb.G), B: mix(ca.B, cb.B), A: 255}
+}
+
+// A tile with a light top/left edge and a dark bottom/right edge so it looks raised
+func raisedTile(face string, light string, dark string) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<rect width="16" height="16" fill="%s"/>
+<polygon points="0,0 16,0 14,2 2,2 2,14 0,16" fill="%s"/>
+<polygon points="16,0 16,16 0,16 2,14 14,14 14,2" fill="%s"/>
+</svg>`, face, light, dark)
+}
+
+// A plain tile with a thin border
+func flatTile(face string, border string) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<rect x="0.5" y="0.5" width="15" height="15" fill="%s" stroke="%s" stroke-width="1"/>
+</svg>`, face, border)
+}
+
+// Corners of a hexagonal cell with its point at the top, in a 16 wide box (the height is 16 * 2/sqrt(3))
+const hexPoints = "8,0 16,4.62 16,13.86 8,18.48 0,13.86 0,4.62"
+
+// A hexagonal tile with light top left edges and dark bottom right edges so it looks raised
+func raisedHexTile(face string, light string, dark string) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 18.48">
+<polygon points="%s" fill="%s"/>
+<polyline points="0,13.86 0,4.62 8,0 16,4.62" fill="none" stroke="%s" stroke-width="3"/>
+<polyline points="16,4.62 16,13.86 8,18.48 0,13.86" fill="none" stroke="%s" stroke-width="3"/>
+</svg>`, hexPoints, face, light, dark)
+}
+
+// A plain hexagonal tile with a thin border
+func flatHexTile(face string, border string) string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 18.48">
+<polygon points="%s" fill="%s" stroke="%s" stroke-width="1.5"/>
+</svg>`, hexPoints, face, border)
+}
+
+// Jagged burst drawn behind a mine that went off, %s is the flag colour
+const blastSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<polygon points="8,0 9.8,4.6 14.5,2.5 12.4,6.7 16,8 12.4,9.3 14.5,13.5 9.8,11.4 8,16 6.2,11.4 1.5,13.5 3.6,9.3 0,8 3.6,6.7 1.5,2.5 6.2,4.6" fill="%s"/>
+</svg>`
+
+// Round mine with spikes and a small shine, %s is the mine colour
+const mineSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<g stroke="%[1]s" stroke-width="1.5" stroke-linecap="round">
+<line x1="8" y1="1.5" x2="8" y2="14.5"/><line x1="1.5" y1="8" x2="14.5" y2="8"/>
+<line x1="3.5" y1="3.5" x2="12.5" y2="12.5"/><line x1="12.5" y1="3.5" x2="3.5" y2="12.5"/>
+</g>
+<circle cx="8" cy="8" r="4.5" fill="%[1]s"/>
+<rect x="5.5" y="5.5" width="2" height="2" fill="#ffffff"/>
+</svg>`
+
+// Flag on a pole, the first %s is the pole colour and the second the flag colour
+const flagSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+<polygon points="9,2 9,9 3,5.5" fill="%[2]s"/>
+<rect x="8.5" y="2" width="1.5" height="10" fill="%[1]s"/>
+<rect x="6" y="11" width="6" height="1.5" fill="%[1]s"/>
+<rect x="4" y="12.5" width="10" height="1.5" fill="%[1]s"/>
+</svg>`
+
+// Fyne theme that keeps the default look but always uses the given light/dark variant
+type variantTheme struct {
+	variant fyne.ThemeVariant
+}
+
+// Gives the Fyne theme for the rest of the window so the buttons and background match the board theme
+// Inputs: None
+// Outputs: Fyne theme to pass to the app's Settings().SetTheme
+func AppTheme() fyne.Theme {
+	return variantTheme{variant: currentTheme().Variant}
+}
+
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return theme.DefaultTheme().Color(name, t.variant)
+}
+
+func (t variantTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t variantTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t variantTheme) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
@@ -0,0 +1,195 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the board topology, which cells count as a cell's neighbors. Everything that looks at neighbors (the
+numbers, the zero flood fill, chording, the opening first click, the AIs, hints and the puzzle checker) asks the game's
+topology, so a new kind of board only has to be added here and drawn (board-widget.go). There are six:
+	square  the classic grid, a cell touches the 8 cells around it
+	hex     hexagonal cells in offset rows, every odd row is pushed half a cell to the right and a cell touches 6 cells:
+	        two in its own row, two in the row above and two in the row below
+	torus   the square grid with its edges joined, the left column touches the right one and the top row the bottom
+	        one, so every cell (corners too) touches exactly 8 cells
+	knight  square cells, the neighbors are the 8 cells a chess knight's move away (none of them share a side)
+	radius2 square cells, the neighbors are the 24 cells at most two steps away in each direction (a 5x5 block)
+	cross   square cells, only the 4 cells sharing a side are neighbors (no diagonals)
+The last three are offsetTopology values, a list of row/col offsets, so another rule like them is one more list
+The grid is picked on the mine setup screen (or with --grid) and is kept in save files and replays
+
+Functions:
+- topologyNamed: Finds a topology by the name stored in the settings/save files
+
+- offsetNeighbors: The cells at a list of row/col offsets that are on the board
+
+- Neighbors (square/hex/torus/offset): The neighbors of a cell
+
+- MaxNeighbors: The most neighbors a cell can have (for the first click's safe zone)
+
+- neighbors: The neighbors of a cell on a game's board
+
+- setTopology: Changes the topology of a game and works the numbers out again
+
+- gridName: The name of a game's topology, for saving it and for the parts that only work on one grid
+
+Inputs:
+- Board size and a cell
+
+Outputs:
+- The cell's neighbors
+*/
+
+package components
+
+import (
+	"minesweeper/config"
+	"slices"
+)
+
+// Topology decides which cells of a board are next to each other
+type Topology interface {
+	Name() string                                  // One of the config.Grid names, saved with the game
+	Neighbors(rows, cols, row, col int) []hardCell // Cells next to row/col that are on the board, never the cell itself
+	MaxNeighbors() int                             // Most neighbors a cell away from the edges has
+}
+
+// The classic grid, every cell touches the 8 around it
+type squareTopology struct{}
+
+// Hexagonal cells in offset rows, odd rows are drawn half a cell to the right
+type hexTopology struct{}
+
+// The square grid with the edges joined, rows and columns wrap around
+type torusTopology struct{}
+
+// Square cells with a neighborhood rule of its own, the neighbors are the cells at fixed row/col offsets
+type offsetTopology struct {
+	name    string
+	offsets [][2]int
+}
+
+// Row/col offsets of the neighbors on each grid, hex rows are shifted so the offsets depend on whether the row is odd
+var (
+	squareOffsets  = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+	hexEvenOffsets = [][2]int{{-1, -1}, {-1, 0}, {0, -1}, {0, 1}, {1, -1}, {1, 0}}
+	hexOddOffsets  = [][2]int{{-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, 0}, {1, 1}}
+	knightOffsets  = [][2]int{{-2, -1}, {-2, 1}, {-1, -2}, {-1, 2}, {1, -2}, {1, 2}, {2, -1}, {2, 1}}
+	radius2Offsets = [][2]int{
+		{-2, -2}, {-2, -1}, {-2, 0}, {-2, 1}, {-2, 2},
+		{-1, -2}, {-1, -1}, {-1, 0}, {-1, 1}, {-1, 2},
+		{0, -2}, {0, -1}, {0, 1}, {0, 2},
+		{1, -2}, {1, -1}, {1, 0}, {1, 1}, {1, 2},
+		{2, -2}, {2, -1}, {2, 0}, {2, 1}, {2, 2},
+	}
+	crossOffsets = [][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+)
+
+// Finds a topology by name, an empty or unknown name (e.g. a file from before there was a choice) is the square grid
+// Inputs: One of the config.Grid names
+// Outputs: The topology
+func topologyNamed(name string) Topology {
+	switch name {
+	case config.GridHex:
+		return hexTopology{}
+	case config.GridTorus:
+		return torusTopology{}
+	case config.GridKnight:
+		return offsetTopology{config.GridKnight, knightOffsets}
+	case config.GridRadius2:
+		return offsetTopology{config.GridRadius2, radius2Offsets}
+	case config.GridCross:
+		return offsetTopology{config.GridCross, crossOffsets}
+	}
+	return squareTopology{}
+}
+
+// Gives the cells at the given offsets from a cell that are on the board
+// Inputs: Board rows/cols, the cell and the row/col offsets
+// Outputs: The cells
+func offsetNeighbors(rows, cols, row, col int, offsets [][2]int) []hardCell {
+	neighbors := make([]hardCell, 0, len(offsets))
+	for _, d := range offsets {
+		r, c := row+d[0], col+d[1]
+		if r >= 0 && r < rows && c >= 0 && c < cols {
+			neighbors = append(neighbors, hardCell{r, c})
+		}
+	}
+	return neighbors
+}
+
+func (squareTopology) Name() string { return config.GridSquare }
+
+func (squareTopology) MaxNeighbors() int { return len(squareOffsets) }
+
+// Neighbors gives the up to 8 cells around a square cell
+func (squareTopology) Neighbors(rows, cols, row, col int) []hardCell {
+	return offsetNeighbors(rows, cols, row, col, squareOffsets)
+}
+
+func (hexTopology) Name() string { return config.GridHex }
+
+func (hexTopology) MaxNeighbors() int { return len(hexEvenOffsets) }
+
+// Neighbors gives the up to 6 cells around a hexagonal cell
+func (hexTopology) Neighbors(rows, cols, row, col int) []hardCell {
+	if row%2 == 1 {
+		return offsetNeighbors(rows, cols, row, col, hexOddOffsets)
+	}
+	return offsetNeighbors(rows, cols, row, col, hexEvenOffsets)
+}
+
+func (torusTopology) Name() string { return config.GridTorus }
+
+func (torusTopology) MaxNeighbors() int { return len(squareOffsets) }
+
+// Neighbors gives the 8 cells around a cell, counting across the edges. A board narrower than 3 cells would reach the
+// same cell from both sides (or the cell itself), those are only counted once
+func (torusTopology) Neighbors(rows, cols, row, col int) []hardCell {
+	neighbors := make([]hardCell, 0, len(squareOffsets))
+	for _, d := range squareOffsets {
+		n := hardCell{(row + d[0] + rows) % rows, (col + d[1] + cols) % cols}
+		if n != (hardCell{row, col}) && !slices.Contains(neighbors, n) {
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors
+}
+
+func (t offsetTopology) Name() string { return t.name }
+
+func (t offsetTopology) MaxNeighbors() int { return len(t.offsets) }
+
+// Neighbors gives the cells at the rule's offsets that are on the board
+func (t offsetTopology) Neighbors(rows, cols, row, col int) []hardCell {
+	return offsetNeighbors(rows, cols, row, col, t.offsets)
+}
+
+// Gives the neighbors of a cell on the game's board, a handler made without a topology uses the square grid
+// Inputs: gameHandler object and row/col of the cell
+// Outputs: The neighbors
+func (handler *Gamehandler) neighbors(row, col int) []hardCell {
+	if handler.topology == nil {
+		return squareTopology{}.Neighbors(handler.rows, handler.cols, row, col)
+	}
+	return handler.topology.Neighbors(handler.rows, handler.cols, row, col)
+}
+
+// Changes the topology of a game, the mines stay where they are and the numbers are worked out again
+// Inputs: gameHandler object and the topology
+// Outputs: None, changes the handler
+func (handler *Gamehandler) setTopology(t Topology) {
+	handler.topology = t
+	handler.AddNumbers()
+}
+
+// Gives the name of the game's topology
+// Inputs: gameHandler object
+// Outputs: One of the config.Grid names
+func (handler *Gamehandler) gridName() string {
+	if handler.topology == nil {
+		return config.GridSquare
+	}
+	return handler.topology.Name()
+}
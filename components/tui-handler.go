@@ -0,0 +1,755 @@
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file is the terminal front-end for the game so it can be played over SSH without a display. It uses the same
+Gamehandler rules as ui-handler.go but draws the board with ANSI escape codes and reads keys from the terminal in raw mode.
+The board has a cursor that is moved with the arrow keys (or hjkl/wasd) and every game mode from the Fyne version is here
+(Single Player, Time Attack, Move Budget, Infinite, AI 1v1, AI Solver and Hot Seat for two players)
+
+Functions:
+- RunTUI: Entry point used by main.go when started with --tui, loops title screen -> mine setup -> game until the user quits
+
+- openTerminal/close: Puts the terminal in raw mode on the alternate screen and puts it back the way it was
+
+- readKeys: Turns raw bytes from stdin into key events (arrow escape sequences, enter, letters...)
+
+- menu/prompt: Simple title/mode/difficulty menus and the mine count and player name text prompts
+
+- playGame: Runs one game, handling cursor movement, reveal/flag/chord and pacing the AI solver, the replay is saved
+when the game ends (replay.go)
+
+- drawGame: Prints the board with the a-j/1-n headers, the cursor and a status line
+
+Inputs:
+- Key presses from the terminal
+
+Outputs:
+- Board drawn in the terminal, updated after each move
+*/
+
+package components
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"minesweeper/config"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/sys/unix"
+)
+
+type tuiKey int
+
+// Key kinds that come out of readKeys, everything printable comes through as keyRune
+const (
+	keyRune tuiKey = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyInterrupt
+)
+
+type tuiEvent struct {
+	key tuiKey
+	ch  rune // Only set for keyRune
+}
+
+// What the user picked once a game has finished (or was left early)
+type tuiAction int
+
+const (
+	tuiRestart tuiAction = iota
+	tuiTitle
+	tuiQuit
+)
+
+// Holds the terminal settings we need to put back and the stream of keys coming in
+type terminal struct {
+	fd    int
+	saved *unix.Termios
+	out   *bufio.Writer
+	keys  chan tuiEvent
+}
+
+// State of the game being played in the terminal
+type tuiGame struct {
+	handler *Gamehandler
+	row     int    // Cursor row
+	col     int    // Cursor col
+	solving bool   // Whether the solver has been started (it starts on the first reveal like in the GUI)
+	saved   bool   // Whether the replay and stats of the finished game were saved, so it only happens once
+	message string // Last thing worth telling the user (bad key, AI moved, ...)
+}
+
+// Entry point for the terminal version of the game, main.go calls this instead of building the Fyne window
+// Inputs: Game flags from the command line, when any are given the menus are skipped for the first game
+// Outputs: An error if the terminal could not be set up, otherwise nil once the user quits
+func RunTUI(launch LaunchOptions) error {
+	// Check the flags before taking over the terminal so a mistake is printed normally
+	var h Gamehandler
+	launched := launch.Wanted()
+	if launched {
+		var err error
+		if h, err = launch.NewGame(); err != nil {
+			return err
+		}
+	}
+
+	term, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer term.close()
+
+	// The AI prints its progress, which would draw over the board
+	aiLog = io.Discard
+
+	for {
+		if !launched {
+			mode, option, ok := term.chooseMode()
+			if !ok {
+				return nil
+			}
+			if mode == "Infinite" { // No board size or mine count to pick
+				h = newInfiniteHandler(time.Now().UnixNano())
+			} else {
+				mines, ok := term.chooseMines()
+				if !ok {
+					continue
+				}
+				h = newModeHandler(mines, mode, option)
+			}
+		}
+		launched = false
+
+		action := term.playGame(&h)
+		for action == tuiRestart {
+			h = h.rematch()
+			action = term.playGame(&h)
+		}
+		if action == tuiQuit {
+			return nil
+		}
+	}
+}
+
+// Puts stdin in raw mode (no echo, no line buffering) and switches to the alternate screen
+// Inputs: None
+// Outputs: terminal object, or an error if stdin is not a terminal
+func openTerminal() (*terminal, error) {
+	fd := int(os.Stdin.Fd())
+	saved, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, fmt.Errorf("--tui needs an interactive terminal: %w", err)
+	}
+
+	raw := *saved
+	raw.Iflag &^= unix.ICRNL | unix.IXON | unix.BRKINT | unix.INPCK | unix.ISTRIP
+	raw.Lflag &^= unix.ECHO | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+
+	term := &terminal{
+		fd:    fd,
+		saved: saved,
+		out:   bufio.NewWriter(os.Stdout),
+		keys:  make(chan tuiEvent, 16),
+	}
+	// Alternate screen + hidden cursor
+	term.out.WriteString("\x1b[?1049h\x1b[?25l")
+	term.out.Flush()
+
+	go term.readKeys()
+	return term, nil
+}
+
+// Puts the terminal back to how it was before the game started
+func (term *terminal) close() {
+	term.out.WriteString("\x1b[0m\x1b[?25h\x1b[?1049l")
+	term.out.Flush()
+	unix.IoctlSetTermios(term.fd, unix.TCSETS, term.saved)
+}
+
+// Reads stdin forever and turns the bytes into key events, arrow keys arrive as ESC [ A-D
+func (term *terminal) readKeys() {
+	buf := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			term.keys <- tuiEvent{key: keyInterrupt}
+			return
+		}
+		in := buf[:n]
+		for len(in) > 0 {
+			switch {
+			case in[0] == 0x1b && len(in) >= 3 && (in[1] == '[' || in[1] == 'O'):
+				switch in[2] {
+				case 'A':
+					term.keys <- tuiEvent{key: keyUp}
+				case 'B':
+					term.keys <- tuiEvent{key: keyDown}
+				case 'C':
+					term.keys <- tuiEvent{key: keyRight}
+				case 'D':
+					term.keys <- tuiEvent{key: keyLeft}
+				}
+				in = in[3:]
+			case in[0] == 0x1b:
+				term.keys <- tuiEvent{key: keyEscape}
+				in = in[1:]
+			case in[0] == '\r' || in[0] == '\n':
+				term.keys <- tuiEvent{key: keyEnter}
+				in = in[1:]
+			case in[0] == 127 || in[0] == 8:
+				term.keys <- tuiEvent{key: keyBackspace}
+				in = in[1:]
+			case in[0] == 3 || in[0] == 4: // Ctrl-C / Ctrl-D
+				term.keys <- tuiEvent{key: keyInterrupt}
+				in = in[1:]
+			default:
+				ch, size := utf8.DecodeRune(in)
+				term.keys <- tuiEvent{key: keyRune, ch: ch}
+				in = in[size:]
+			}
+		}
+	}
+}
+
+// Clears the screen and moves to the top left, all drawing starts with this
+func (term *terminal) clear() {
+	term.out.WriteString("\x1b[H\x1b[2J")
+}
+
+// Writes a line, raw mode doesn't turn \n into \r\n for us
+func (term *terminal) line(format string, args ...any) {
+	fmt.Fprintf(term.out, format, args...)
+	term.out.WriteString("\r\n")
+}
+
+// Shows a list of options and lets the user pick one with the arrows/jk (or the option number) and enter
+// Inputs: Title shown above the options and the options themselves
+// Outputs: Index of the picked option, or -1 if the user backed out with escape
+func (term *terminal) menu(title string, options []string) int {
+	selected := 0
+	for {
+		term.clear()
+		term.line("")
+		term.line("  \x1b[1;32m%s\x1b[0m", title)
+		term.line("")
+		for i, opt := range options {
+			if i == selected {
+				term.line("  \x1b[7m %d. %s \x1b[0m", i+1, opt)
+			} else {
+				term.line("   %d. %s", i+1, opt)
+			}
+		}
+		term.line("")
+		term.line("  \x1b[2marrows/jk move  enter select  esc back\x1b[0m")
+		term.out.Flush()
+
+		ev := <-term.keys
+		switch {
+		case ev.key == keyUp || ev.ch == 'k' || ev.ch == 'w':
+			selected = (selected + len(options) - 1) % len(options)
+		case ev.key == keyDown || ev.ch == 'j' || ev.ch == 's':
+			selected = (selected + 1) % len(options)
+		case ev.key == keyEnter:
+			return selected
+		case ev.key == keyEscape || ev.key == keyInterrupt:
+			return -1
+		case ev.ch >= '1' && int(ev.ch-'1') < len(options):
+			return int(ev.ch - '1')
+		}
+	}
+}
+
+// Asks the user to type a value
+// Inputs: Label to show, starting text and an error message from the last attempt (empty if none)
+// Outputs: Typed text and false if the user backed out with escape
+func (term *terminal) prompt(label string, text string, errMsg string) (string, bool) {
+	for {
+		term.clear()
+		term.line("")
+		term.line("  %s", label)
+		term.line("")
+		term.line("  > %s\x1b[7m \x1b[0m", text)
+		term.line("")
+		if errMsg != "" {
+			term.line("  \x1b[31m%s\x1b[0m", errMsg)
+		}
+		term.out.Flush()
+
+		ev := <-term.keys
+		switch ev.key {
+		case keyEnter:
+			return text, true
+		case keyEscape, keyInterrupt:
+			return "", false
+		case keyBackspace:
+			if len(text) > 0 {
+				text = text[:len(text)-1]
+			}
+		case keyRune:
+			text += string(ev.ch)
+		}
+	}
+}
+
+// Title screen -> game mode -> AI difficulty, same flow as gameSelect/showAImode in setup.go
+// Inputs: None
+// Outputs: mode/option in the form newModeHandler takes, false if the user chose to exit
+func (term *terminal) chooseMode() (string, string, bool) {
+	for {
+		picked := term.menu("MINESWEEPER 2", []string{"Single Player", "Time Attack", "Move Budget", "Infinite",
+			"AI 1v1 Mode", "AI Solver Mode", "Hot Seat (2 Players)", "Exit"})
+		switch picked {
+		case 0:
+			return "Single", "Play", true
+		case 1, 2:
+			mode, limits, title := "Timed", config.TimeLimits, "Select the time limit:"
+			if picked == 2 {
+				mode, limits, title = "Moves", config.MoveLimits, "Select the move budget:"
+			}
+			options := make([]string, len(limits))
+			for i, limit := range limits {
+				options[i] = limitTitle(mode, strconv.Itoa(limit))
+			}
+			level := term.menu(title, options)
+			if level < 0 {
+				continue
+			}
+			return mode, strconv.Itoa(limits[level]), true
+		case 3:
+			return "Infinite", "Play", true
+		case 4, 5:
+			difficulties := []string{"Easy", "Medium", "Hard"}
+			level := term.menu("Select AI Difficulty:", difficulties)
+			if level < 0 {
+				continue
+			}
+			if picked == 4 {
+				return "AI", difficulties[level], true
+			}
+			return "Solve", difficulties[level], true
+		case 6:
+			names, ok := term.choosePlayers()
+			if !ok {
+				continue
+			}
+			return "Hotseat", hotseatOption(names), true
+		default:
+			return "", "", false
+		}
+	}
+}
+
+// Asks for the two hot-seat players' names until both are fine, same rules as showHotseatSetup
+// Inputs: None
+// Outputs: Names and false if the user backed out
+func (term *terminal) choosePlayers() ([2]string, bool) {
+	typed := [2]string(config.Current.HotseatNames)
+	errMsg := ""
+	for {
+		for i := range typed {
+			text, ok := term.prompt(fmt.Sprintf("Player %d name:", i+1), typed[i], errMsg)
+			if !ok {
+				return typed, false
+			}
+			typed[i] = text
+			errMsg = ""
+		}
+		names, err := playerNames(typed[0], typed[1])
+		if err == nil {
+			config.Current.HotseatNames = names[:]
+			if err := config.SaveSettings(); err != nil {
+				errMsg = "Could not save the names: " + err.Error()
+			}
+			return names, true
+		}
+		errMsg = err.Error()
+	}
+}
+
+// Asks for the mine count until a valid one is typed, same rules as showMineSetup
+// Inputs: None
+// Outputs: Mine count and false if the user backed out
+func (term *terminal) chooseMines() (int, bool) {
+	lo, hi := config.MineLimits(config.Current.Rows, config.Current.Cols)
+	label := fmt.Sprintf("Select number of mines (%d-%d):", lo, hi)
+	text := strconv.Itoa(config.Current.Mines)
+	errMsg := ""
+	for {
+		typed, ok := term.prompt(label, text, errMsg)
+		if !ok {
+			return 0, false
+		}
+		n, err := parseMineCount(typed)
+		if err == nil {
+			return n, true
+		}
+		text = typed
+		errMsg = err.Error()
+	}
+}
+
+// Runs a single game until it is left through restart/title/quit
+// Inputs: The game to play (made from the menus or the command line flags)
+// Outputs: What the user wants to do next
+func (term *terminal) playGame(h *Gamehandler) tuiAction {
+	game := &tuiGame{handler: h}
+
+	// The solver is paced by a ticker here instead of the sleeps in afterPlayerReveal so keys still work while it plays
+	ticker := time.NewTicker(solverDelay())
+	defer ticker.Stop()
+	var solverTick <-chan time.Time
+
+	// A Time Attack game counts down on its own, the screen is drawn again every tick to show the time left
+	var clock <-chan time.Time
+	if h.timeLimit > 0 {
+		clockTicker := time.NewTicker(clockTick)
+		defer clockTicker.Stop()
+		clock = clockTicker.C
+	}
+
+	for {
+		if game.solving && solverTick == nil {
+			solverTick = ticker.C
+		}
+		if h.gameOver && !game.saved && h.infinite == nil { // An infinite board has no replay
+			if _, err := SaveReplay(replayOf(h)); err != nil {
+				game.message = "Could not save the replay: " + err.Error()
+			}
+			if err := recordGame(h); err != nil {
+				game.message = "Could not save the game stats: " + err.Error()
+			}
+		}
+		game.saved = h.gameOver
+		term.drawGame(game)
+
+		select {
+		case ev := <-term.keys:
+			if action, done := game.handleKey(ev); done {
+				return action
+			}
+		case <-clock:
+			h.checkClock()
+		case <-solverTick:
+			if h.gameOver || !h.aiStep() {
+				h.aiTurn = false
+				solverTick = nil
+				game.solving = false
+			}
+		}
+	}
+}
+
+// Deals with a key during the game
+// Inputs: Key event
+// Outputs: Action and true if the game should be left
+func (game *tuiGame) handleKey(ev tuiEvent) (tuiAction, bool) {
+	game.message = ""
+	switch {
+	case ev.key == keyInterrupt || ev.ch == 'q':
+		return tuiQuit, true
+	case ev.key == keyEscape || ev.ch == 't':
+		return tuiTitle, true
+	case ev.ch == 'n':
+		return tuiRestart, true
+	case ev.key == keyUp || ev.ch == 'k' || ev.ch == 'w':
+		game.moveCursor(-1, 0)
+	case ev.key == keyDown || ev.ch == 'j' || ev.ch == 's':
+		game.moveCursor(1, 0)
+	case ev.key == keyLeft || ev.ch == 'h' || ev.ch == 'a':
+		game.moveCursor(0, -1)
+	case ev.key == keyRight || ev.ch == 'l' || ev.ch == 'd':
+		game.moveCursor(0, 1)
+	case ev.key == keyEnter || ev.ch == ' ':
+		game.reveal()
+	case ev.ch == 'f':
+		game.flag()
+	case ev.ch == 'c':
+		game.chord()
+	}
+	return tuiRestart, false
+}
+
+// Moves the cursor, stopping at the board edges (or going round to the other side on a wrap-around board). On the
+// infinite board the window scrolls instead when the cursor would go past its edge
+func (game *tuiGame) moveCursor(dr int, dc int) {
+	h := game.handler
+	if h.infinite != nil {
+		row, col := game.row+dr, game.col+dc
+		h.scrollView(row-min(max(row, 0), h.rows-1), col-min(max(col, 0), h.cols-1))
+	}
+	if h.gridName() == config.GridTorus {
+		game.row = (game.row + dr + h.rows) % h.rows
+		game.col = (game.col + dc + h.cols) % h.cols
+		return
+	}
+	game.row = min(max(game.row+dr, 0), h.rows-1)
+	game.col = min(max(game.col+dc, 0), h.cols-1)
+}
+
+// Whether the user is allowed to touch the board right now (same checks as Tapped in ui-handler.go)
+func (game *tuiGame) canPlay() bool {
+	h := game.handler
+	if h.checkClock() || h.gameOver {
+		return false
+	}
+	if (h.aiEnabled || h.aiSolver) && h.aiTurn {
+		game.message = "Wait for the AI to finish its move."
+		return false
+	}
+	if h.waitingForPlayer() { // The first move key of the next hot-seat player starts their turn
+		h.startTurn()
+		game.message = turnText(h) + ", go ahead."
+		return false
+	}
+	return true
+}
+
+// Left click equivalent on the cursor cell
+func (game *tuiGame) reveal() {
+	if !game.canPlay() {
+		return
+	}
+	sq := game.handler.board[game.row][game.col]
+	if sq.state == Uncovered || sq.state == Flagged {
+		return
+	}
+	game.handler.Click(game.row, game.col)
+	game.afterMove()
+}
+
+// Right click equivalent on the cursor cell
+func (game *tuiGame) flag() {
+	if !game.canPlay() {
+		return
+	}
+	if game.handler.board[game.row][game.col].state == Uncovered {
+		return
+	}
+	game.handler.ToggleFlag(game.row, game.col)
+	if game.handler.aiEnabled && !game.handler.gameOver {
+		game.aiReply()
+	}
+}
+
+// Reveals around the number under the cursor if it has the right amount of flags
+func (game *tuiGame) chord() {
+	if !game.canPlay() {
+		return
+	}
+	if !game.handler.Chord(game.row, game.col) {
+		game.message = "Chord needs a number with exactly that many flags around it."
+		return
+	}
+	game.afterMove()
+}
+
+// After the user reveals something the AI gets its turn (1v1) or the solver takes over
+func (game *tuiGame) afterMove() {
+	h := game.handler
+	if h.gameOver {
+		return
+	}
+	if h.aiEnabled {
+		game.aiReply()
+	} else if h.aiSolver && !game.solving {
+		h.aiTurn = true
+		game.solving = true
+	}
+}
+
+// Lets the 1v1 AI make its single move
+func (game *tuiGame) aiReply() {
+	h := game.handler
+	h.aiTurn = true
+	h.RunAIMove()
+	h.aiTurn = false
+	game.message = "AI moved."
+}
+
+// Gives the character used for a cell in the terminal/text front-ends ("#" covered, "F" flag, "?" question mark,
+// "b" bomb, "X" a mine that went off in a lives game, "." empty), a cell with several flags or mines gets the count
+// after the letter (e.g. "F2")
+// Inputs: Square to draw
+// Outputs: Single character string, or two characters for a count
+func cellSymbol(sq Square) string {
+	switch {
+	case sq.state == Flagged && sq.flags > 1:
+		return "F" + strconv.Itoa(sq.flags)
+	case sq.state == Flagged:
+		return "F"
+	case sq.state == Questioned:
+		return "?"
+	case sq.state == Covered:
+		return "#"
+	case sq.exploded && sq.bombs > 1:
+		return "X" + strconv.Itoa(sq.bombs)
+	case sq.exploded:
+		return "X"
+	case sq.bombs > 1:
+		return "b" + strconv.Itoa(sq.bombs)
+	case sq.isBomb():
+		return "b"
+	case sq.numValue == 0:
+		return "."
+	}
+	return strconv.Itoa(sq.numValue)
+}
+
+// Gives how many characters a cell symbol can take, two when numbers can reach 10 (radius 2, or cells with several
+// mines) or a cell can show a mine/flag count
+// Inputs: The game
+// Outputs: Width for the cell symbols
+func symbolWidth(h *Gamehandler) int {
+	if topologyNamed(h.gridName()).MaxNeighbors() >= 10 || h.cellMines > 1 {
+		return 2
+	}
+	return 1
+}
+
+// Gives the ANSI colour for a cell, colours follow the Fyne board (green numbers, yellow when the AI revealed it, red flags)
+// Inputs: Square to draw
+// Outputs: ANSI colour escape code
+func tuiColour(sq Square) string {
+	switch {
+	case sq.state == Flagged:
+		return "\x1b[1;31m"
+	case sq.state == Questioned:
+		return "\x1b[1;36m"
+	case sq.state == Covered:
+		return "\x1b[90m"
+	case sq.exploded:
+		return "\x1b[1;37;41m" // White on red like the burst on the Fyne board
+	case sq.isBomb():
+		return "\x1b[1;31m"
+	case sq.player == 1:
+		return "\x1b[1;97;44m" // The hot-seat players' colours as backgrounds, white on blue
+	case sq.player == 2:
+		return "\x1b[1;30;43m" // Black on yellow
+	case sq.numValue == 0:
+		return "\x1b[2m"
+	case sq.markedByAI && config.Current.AIMarkers:
+		return "\x1b[4;33m" // Underlined as well so it does not rely on colour
+	case sq.markedByAI:
+		return "\x1b[33m"
+	}
+	return "\x1b[32m"
+}
+
+// Draws the whole game screen: title, headers, board with the cursor, status line and the key help
+// Inputs: The game being played
+// Outputs: None, writes to the terminal
+func (term *terminal) drawGame(game *tuiGame) {
+	h := game.handler
+	term.clear()
+	term.line("")
+	term.line("  \x1b[1;32mMINESWEEPER 2\x1b[0m  %s", modeTitle(handlerMode(h)))
+	term.line("")
+
+	// Column headers
+	width := symbolWidth(h)
+	header := strings.Builder{}
+	header.WriteString("     ")
+	for c := 0; c < h.cols; c++ {
+		fmt.Fprintf(&header, "%-*s", width+2, " "+columnLabel(c))
+	}
+	term.line("%s", header.String())
+
+	for r := 0; r < h.rows; r++ {
+		row := strings.Builder{}
+		fmt.Fprintf(&row, "  %2d ", r+1)
+		if h.gridName() == config.GridHex && r%2 == 1 {
+			row.WriteString(" ") // Odd hex rows sit between the cells of the rows around them
+		}
+		for c := 0; c < h.cols; c++ {
+			sq := h.board[r][c]
+			if r == game.row && c == game.col {
+				fmt.Fprintf(&row, "\x1b[7m[%*s]\x1b[0m", width, cellSymbol(sq))
+			} else {
+				fmt.Fprintf(&row, " %s%*s\x1b[0m ", tuiColour(sq), width, cellSymbol(sq))
+			}
+		}
+		if h.gridName() == config.GridTorus {
+			row.WriteString("\x1b[2m ~\x1b[0m") // The right edge joins the left one
+		}
+		term.line("%s", row.String())
+	}
+	if h.gridName() == config.GridTorus {
+		term.line("     \x1b[2m%s\x1b[0m", strings.Repeat(" ~ ", h.cols)) // And the bottom edge the top one
+	}
+	term.line("")
+
+	// Status line
+	turn := "You"
+	if h.aiTurn {
+		turn = "AI"
+	} else if h.hotseat != nil {
+		turn = h.hotseat.names[h.hotseat.turn]
+	}
+	extra := ""
+	for _, text := range []string{livesText(h), scoreText(h)} {
+		if text != "" {
+			extra += "  " + text
+		}
+	}
+	mines := fmt.Sprintf("Mines: %d  Flags: %d", h.totalMines, h.flagCount())
+	if h.infinite != nil {
+		mines = fmt.Sprintf("Mines: %d in every %dx%d chunk", config.InfiniteMines, config.InfiniteChunk, config.InfiniteChunk)
+	}
+	term.line("  %s%s  Turn: %s  Cell: %s", mines, extra, turn, describeCell(h, game.row, game.col))
+	if h.hotseat != nil { // Scoreboard, each name in the colour of its cells
+		term.line("  %s %s \x1b[0m  %s %s \x1b[0m", tuiColour(Square{state: Uncovered, player: 1}), playerText(h, 0),
+			tuiColour(Square{state: Uncovered, player: 2}), playerText(h, 1))
+	}
+
+	switch {
+	case h.gameOver && h.win:
+		term.line("  \x1b[1;33m%s\x1b[0m  n new game  t title screen  q quit", describeResult(h))
+	case h.gameOver:
+		term.line("  \x1b[1;31m%s\x1b[0m  n new game  t title screen  q quit", describeResult(h))
+	default:
+		term.line("  %s", game.message)
+	}
+	term.line("")
+	term.line("  \x1b[2marrows/hjkl/wasd move  space/enter reveal  f flag  c chord  n new  esc/t title  q quit\x1b[0m")
+	term.out.Flush()
+}
+
+// Gives a readable name for the selected mode for the title line
+func modeTitle(mode string, option string) string {
+	switch mode {
+	case "AI":
+		return "AI 1v1 (" + option + ")"
+	case "Solve":
+		return "AI Solver (" + option + ")"
+	case "Timed":
+		return "Time Attack (" + limitTitle(mode, option) + ")"
+	case "Moves":
+		return "Move Budget (" + limitTitle(mode, option) + ")"
+	case "Infinite":
+		return "Infinite"
+	case "Hotseat":
+		names := hotseatNames(option)
+		return "Hot Seat (" + names[0] + " vs " + names[1] + ")"
+	}
+	return "Single Player"
+}
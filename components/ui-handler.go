@@ -10,17 +10,36 @@ game-handler and creates the GUI from that. This will create the visual grid, cr
 It also displays the win/lose message.
 
 Functions:
-- SetupGameGraphics: Initializes all GUI parts for the board creating the initial cells/win & lose message (keeping them inivisble)
+- SetupGameGraphics: Initializes all GUI parts for a game: the board widget (board-widget.go draws the cells and handles
+the clicks), the zoom and save buttons (and the arrows that scroll an infinite board, infinite.go) and the win & lose message (keeping it inivisble). Everything belongs to that one game screen
+so nothing is kept in package variables
 
-- Tapped: Handles all left clicks
+- revealCell/flagCell/chordCell: The player moves shared by the mouse and keyboard (keyboard.go), each one saves an undo point first.
+In a puzzle (puzzle.go) reveals and flags the numbers don't prove are refused, and in a hot-seat game (hotseat.go) no move
+is taken between turns until the next player presses the button over the board
 
-- TappedSecondary: Handles all right clicks
+- afterPlayerReveal: Gives the AI its move (1v1) or starts the solver after the player reveals something
 
-- applyOverlayStates: Update overlay visibility and colors based on the state of the cell (uncovered, covered, flagged, etc.). This is meant so when updating the states of the cells upon clicking it will properly reflect it on the visual side
+- ShowGame: Shows a game in the window, used by main.go when the game was picked with command line flags
 
-- updateCellTexts: Updates the text inside the cell, useful for if the board had to be regenerated due to a "first left click on bomb" as the numbers in the 2d array wouldn't be updated alone by applyOverlayStates
+- saveGameAs: Asks where to save the game and saves it (savegame.go)
 
-- UpdateGameUI: Used to refresh both celltext/overlay states in the correct order as well as check the win condition upon which it will show some text overlays (i.e. end of game message)
+- exportBoardAs: Asks where to write the board's mines and writes them as a layout grid (layout.go)
+
+- showGameStats: Shows the totals of normal and lives games (Stats button after a single player game)
+
+- restartGame: Starts a new game with the same settings (Restart button/restart key)
+
+- update: Refreshes the board widget (it only redraws cells that changed) and shows the end of game message once the game is over,
+saving the game's replay (replay.go) the first time it ends, the progress when a puzzle is solved, the result of a
+daily challenge and the totals of single player games (stats.go). The lives left are shown above the board (lives.go),
+and so are the time or moves left and the score of the score modes (scoremode.go) and the hot-seat scoreboard
+
+- runClock: Counts down a Time Attack game while it is shown
+
+- announce: Shows a description of the last move under the board and speaks it if speech is on (accessibility.go)
+
+- UpdateGameUI: Asks whichever screen is showing a game to update, used after a move is made
 
 Input:
 - Board state from game-handler
@@ -35,360 +54,538 @@ Output:
 package components
 
 import (
+	"fmt"
 	"minesweeper/config"
+	"strings"
 	"time"
 
 	"image/color"
-	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-var (
-	cellOverlays [][]*canvas.Rectangle
-	cellFlags    [][]*canvas.Text
-	cellTexts    [][]*canvas.Text
-	gameMsg      *canvas.Text
-
-	gameOverContainer *fyne.Container
-	newGameButton     *widget.Button
-	titleScreenButton *widget.Button
-)
-
-type clickableRect struct {
-	*canvas.Rectangle
-	row     int
-	col     int
-	handler *Gamehandler
+// Everything on screen for one game, SetupGameGraphics makes one of these per game
+type gameScreen struct {
+	handler  *Gamehandler
+	board    *BoardWidget
+	message  *canvas.Text    // End of game message
+	gameOver *fyne.Container // End of game message + restart/title buttons, hidden until the game ends
+	lives    *widget.Label   // Lives left in the bar above the board, hidden for the classic game (lives.go)
+	score    *widget.Label   // Time or moves left and the score, hidden unless the game is played for a score (scoremode.go)
+
+	scoreboard *fyne.Container  // Hot-seat scoreboard (hotseat.go)
+	players    [2]*widget.Label // Scoreboard lines, the player whose turn it is is in bold
+	handover   *widget.Button   // Starts the next hot-seat player's turn, only shown between turns
+
+	announcement *widget.Label // Description of the last move/cell under the board, for screen readers (accessibility.go)
+	announced    bool          // Whether the result of the game was already announced
 }
 
-var _ fyne.Tappable = (*clickableRect)(nil)
-var _ fyne.SecondaryTappable = (*clickableRect)(nil)
-
 /*
-Called upon left click, will check if game is already over (Not allow gameplay past loss/win) and then afterwards calls game-handler.go's Click function to handle the backend click and then updates the game ui based on what that did
+Player reveal (left click or the reveal key), will check if game is already over (Not allow gameplay past loss/win) and then afterwards calls game-handler.go's Click function to handle the backend click and then updates the game ui based on what that did
 */
-func (c *clickableRect) Tapped(_ *fyne.PointEvent) {
-	if c.handler.gameOver {
+func revealCell(handler *Gamehandler, row int, col int) {
+	if handler.checkClock() {
+		UpdateGameUI(handler)
+	}
+	if handler.gameOver {
 		return
 	}
 	// Zhang: prevent user from clicking when it's AI's turn
-	if c.handler.aiEnabled && c.handler.aiTurn {
+	if handler.aiEnabled && handler.aiTurn {
+		return
+	}
+	if handler.waitingForPlayer() { // The next hot-seat player hasn't started their turn
 		return
 	}
-	sq := &c.handler.board[c.row][c.col]
+	sq := &handler.board[row][col]
 
 	if sq.state == Uncovered || sq.state == Flagged {
 		return
 	}
-	c.handler.Click(c.row, c.col)
-	UpdateGameUI(c.handler)
-
-	if c.handler.aiEnabled && !c.handler.gameOver {
-		c.handler.aiTurn = true
-		c.handler.RunAIMove()
-		c.handler.aiTurn = false
-		UpdateGameUI(c.handler)
-	} else if c.handler.aiSolver && !c.handler.gameOver {
-		c.handler.aiTurn = true
-		go func() { // Run the AI solver in a separate goroutine
-			for !c.handler.gameOver {
-				c.handler.RunAIMove()
-				UpdateGameUI(c.handler)
-				time.Sleep(1000 * time.Millisecond) // Pause for one second between moves
-			}
-			c.handler.aiTurn = false
-		}()
+	if !handler.puzzleAllows(MoveReveal, row, col) {
+		return
 	}
+	handler.saveUndo()
+	handler.Click(row, col)
+	UpdateGameUI(handler)
+	afterPlayerReveal(handler)
 }
 
 /*
-Called upon right click, checks if game over and then turns the underlining 2d-array to have a flag state and then refresh the game ui
+Player flag (right click or the flag key), checks if game over and then turns the underlining 2d-array to have a flag state and then refresh the game ui
 */
-func (c *clickableRect) TappedSecondary(_ *fyne.PointEvent) {
-	if c.handler.gameOver { // ignore flags after game over
+func flagCell(handler *Gamehandler, row int, col int) {
+	if handler.checkClock() {
+		UpdateGameUI(handler)
+	}
+	if handler.gameOver { // ignore flags after game over
 		return
 	}
-	if c.handler.aiEnabled && c.handler.aiTurn { // Zhang: prevent user from flagging when it's AI's turn
+	if handler.aiEnabled && handler.aiTurn { // Zhang: prevent user from flagging when it's AI's turn
 		return
 	}
-	sq := &c.handler.board[c.row][c.col]
+	if handler.waitingForPlayer() {
+		return
+	}
+	sq := &handler.board[row][col]
 	if sq.state == Uncovered {
 		return
 	}
-	c.handler.ToggleFlag(c.row, c.col)
-	UpdateGameUI(c.handler)
-
-	if c.handler.aiEnabled && !c.handler.gameOver { // Zhang: let AI make a move after user right clicks
-		c.handler.aiTurn = true
-		EasyAIMove(c.handler)
-		c.handler.aiTurn = false
-		UpdateGameUI(c.handler)
+	if !handler.puzzleAllows(MoveFlag, row, col) {
+		return
+	}
+	handler.saveUndo()
+	handler.ToggleFlag(row, col)
+	UpdateGameUI(handler)
+
+	if handler.aiEnabled && !handler.gameOver { // Zhang: let AI make a move after user right clicks
+		handler.aiTurn = true
+		EasyAIMove(handler)
+		handler.aiTurn = false
+		UpdateGameUI(handler)
 	}
 }
 
-// Helper function: Used to simplify r.move() operations
-func cellPos(col, row int) fyne.Position {
-	return fyne.NewPos(
-		float32(col*config.GridSpacing),
-		float32(row*config.GridSpacing),
-	)
+/*
+Player chord (chord key), reveals around a number that already has that many flags next to it. Puzzles allow every
+chord since their flags are always right
+*/
+func chordCell(handler *Gamehandler, row int, col int) {
+	if handler.checkClock() {
+		UpdateGameUI(handler)
+	}
+	if handler.gameOver || (handler.aiEnabled && handler.aiTurn) || handler.waitingForPlayer() {
+		return
+	}
+	handler.saveUndo()
+	if !handler.Chord(row, col) {
+		handler.dropUndo() // Nothing happened so there is nothing to undo
+		return
+	}
+	UpdateGameUI(handler)
+	afterPlayerReveal(handler)
 }
 
-// This Function is Intended to be used as a one time initializer for the game's UI components
-// Inputs: 2D-Array of the board and the gameHandler object to get the context of the object for the click handler
-// Outputs: A fyne container which can store multiple elements
-func SetupGameGraphics(board [][]Square, handler *Gamehandler) *fyne.Container {
-	var columnNames string = "abcdefghijklmnopqrstuvwxyz"
-
-	// Initialize storage variables for Overlays/flags/Textboxes
-	// Create "Cells" on top of each box to show/not show depending on state
-	cellOverlays = make([][]*canvas.Rectangle, config.BoardSize)
-	cellFlags = make([][]*canvas.Text, config.BoardSize)
-	for r := range cellOverlays {
-		cellOverlays[r] = make([]*canvas.Rectangle, config.BoardSize)
-		cellFlags[r] = make([]*canvas.Text, config.BoardSize)
-	}
-	cellTexts = make([][]*canvas.Text, config.BoardSize)
-	for r := range cellTexts {
-		cellTexts[r] = make([]*canvas.Text, config.BoardSize)
-	}
-
-	// Used as an array to "loop" over in order so to ensure proper "layering" of each item (did * 5 just to ensure extra space not really needed to be this big)
-	objects := make([]fyne.CanvasObject, 0, (config.BoardSize+1)*(config.BoardSize+1)*5)
-
-	// Loop overboard setting row/column headers (row/col == 0 lines), if the cell is a body cell instead we "draw" the text for that cell (Bomb/neighbors)
-	for row := 0; row < (config.BoardSize + 1); row++ {
-		for col := 0; col < (config.BoardSize + 1); col++ {
-			if row == 0 && col == 0 {
-				continue
-			} else if row == 0 {
-				r := canvas.NewText(columnNames[col-1:col], color.RGBA{255, 255, 255, 255})
-				r.TextSize = float32(config.GridSpacing) / 2
-				sz := r.MinSize()
-				cell := float32(config.GridSpacing)
-				x := float32(col*config.GridSpacing) + (cell-sz.Width)/2
-				y := float32(0*config.GridSpacing) + (cell-sz.Height)/2
-				r.Move(fyne.NewPos(x, y))
-				objects = append(objects, r)
-			} else if col == 0 {
-				r := canvas.NewText(strconv.Itoa(row), color.RGBA{255, 255, 255, 255})
-				r.TextSize = float32(config.GridSpacing) / 2
-				sz := r.MinSize()
-				cell := float32(config.GridSpacing)
-				x := float32(0*config.GridSpacing) + (cell-sz.Width)/2
-				y := float32(row*config.GridSpacing) + (cell-sz.Height)/2
-				r.Move(fyne.NewPos(x, y))
-				objects = append(objects, r)
-			} else {
-				// Draw underlying cell content (bomb or number)
-				c := board[row-1][col-1]
-				var txt string
-				if c.isBomb {
-					txt = "b"
-				} else if c.numValue != 0 {
-					txt = strconv.Itoa(c.numValue)
+/*
+After the player reveals something the AI gets its move (1v1) or the solver takes over
+*/
+func afterPlayerReveal(handler *Gamehandler) {
+	if handler.aiEnabled && !handler.gameOver {
+		handler.aiTurn = true
+		handler.RunAIMove()
+		handler.aiTurn = false
+		UpdateGameUI(handler)
+	} else if handler.aiSolver && !handler.gameOver {
+		handler.aiTurn = true
+		win := fyne.CurrentApp().Driver().AllWindows()[0]
+		content := win.Content()
+		go func() { // Run the AI solver in a separate goroutine
+			for {
+				// The move redraws the board and saves the replay and stats, that has to happen on the Fyne thread
+				moved := false
+				fyne.DoAndWait(func() {
+					if win.Content() != content {
+						return // The game was left (Restart, Title Screen), it isn't played on in the background
+					}
+					if moved = handler.RunAIMove(); moved {
+						UpdateGameUI(handler)
+					} else {
+						handler.aiTurn = false
+					}
+				})
+				if !moved {
+					return
 				}
-				base := canvas.NewText(txt, color.RGBA{0, 255, 0, 255})
-				base.TextSize = config.GridSpacing / 2
-
-				// Center Text
-				size := base.MinSize()
-				cellSize := float32(config.GridSpacing)
-
-				x := float32(col*config.GridSpacing) + (cellSize-size.Width)/2
-				y := float32(row*config.GridSpacing) + (cellSize-size.Height)/2
-				base.Move(fyne.NewPos(x, y))
-
-				objects = append(objects, base)
-				cellTexts[row-1][col-1] = base
+				time.Sleep(solverDelay()) // Pause between moves (AI delay in the settings)
 			}
-		}
+		}()
 	}
+}
 
-	// As of this point the "cells" above havce the underlining neighbor/bomb/row & col header but the covering "cell" bit that you can click isn't on there so this re loops through and places them
-	// We first create the rectangles objects and place them where they go setting their colors and what not
-	for rw := 0; rw < config.BoardSize; rw++ {
-		for c := 0; c < config.BoardSize; c++ {
-			// overlay rectangle
-			overlay := canvas.NewRectangle(color.NRGBA{R: 60, G: 60, B: 60, A: 255})
-			overlay.Resize(fyne.NewSize(float32(config.GridSpacing), float32(config.GridSpacing)))
-			overlay.Move(cellPos(c+1, rw+1)) // adjust for header
-
-			overlay.StrokeColor = color.NRGBA{R: 30, G: 30, B: 30, A: 255}
-			overlay.StrokeWidth = 1
-
-			clickable := &clickableRect{
-				Rectangle: overlay,
-				row:       rw,
-				col:       c,
-				handler:   handler,
-			}
-
-			cellOverlays[rw][c] = overlay
-
-			// Flag
-			flag := canvas.NewText("F", color.NRGBA{R: 220, G: 40, B: 40, A: 255})
-			flag.TextSize = config.GridSpacing / 2
-			flag.TextStyle.Bold = true
-			size := flag.MinSize()
+// This Function is Intended to be used as a one time initializer for the game's UI components
+// Inputs: 2D-Array of the board (not needed anymore, the board widget reads it from the handler) and the gameHandler object for the game
+// Outputs: A fyne container with the zoom and save buttons on top and the (scrollable) board under them
+func SetupGameGraphics(_ [][]Square, handler *Gamehandler) *fyne.Container {
+	screen := &gameScreen{handler: handler}
+	screen.board = NewBoardWidget(handler)
+
+	// Let the game handler ask for a redraw whenever a click changes the board (the solver relies on this)
+	handler.onChange = screen.update
+
+	// Cell descriptions from the board go to a line under it (and are spoken if that is turned on)
+	screen.announcement = widget.NewLabel("")
+	screen.announcement.Wrapping = fyne.TextWrapWord
+	screen.board.OnAnnounce = screen.announce
+
+	// The "end game" message object, it sits on top of the board and is centered by its container (hidden initially)
+	screen.message = canvas.NewText("", color.White)
+	screen.message.TextStyle.Bold = true
+	screen.message.TextSize = 40
+
+	newGameButton := widget.NewButton("Restart", func() {
+		restartGame(handler)
+	})
 
-			// Center Text
-			cellSize := float32(config.GridSpacing)
-			x := float32((c+1)*config.GridSpacing) + (cellSize-size.Width)/2
-			y := float32((rw+1)*config.GridSpacing) + (cellSize-size.Height)/2
-			flag.Move(fyne.NewPos(x, y))
-			cellFlags[rw][c] = flag
+	titleScreenButton := widget.NewButton("Title Screen", func() {
+		win := fyne.CurrentApp().Driver().AllWindows()[0]
+		LoadSetupInto(win)
+	})
 
-			objects = append(objects, clickable.Rectangle, clickable, flag)
-		}
+	// The daily challenge can't be restarted, its leaderboard is shown instead
+	dailyButton := widget.NewButton("Leaderboard", func() {
+		showDaily(fyne.CurrentApp().Driver().AllWindows()[0])
+	})
+	if handler.daily == "" {
+		dailyButton.Hide()
+	} else {
+		newGameButton.Hide()
 	}
 
-	// Finally we create the "end game" message object
-	// We also make sure it is centered (hidden initially)
-	gameMsg = canvas.NewText("", color.White)
-	gameMsg.TextStyle.Bold = true
-	gameMsg.TextSize = float32(config.GridSpacing) * 0.9
+	// Back to the puzzle list after a puzzle, hidden for other games
+	puzzlesButton := widget.NewButton("Puzzles", func() {
+		showPuzzles(fyne.CurrentApp().Driver().AllWindows()[0], handler.puzzle.pack)
+	})
+	if handler.puzzle == nil {
+		puzzlesButton.Hide()
+	}
 
-	newGameButton = widget.NewButton("Restart", func() {
-		win := fyne.CurrentApp().Driver().AllWindows()[0]
-		mineCount := handler.totalMines
-		h := NewGameHandler(mineCount)
-		if handler.aiEnabled {
-			h.setAIEnabled(true)
-			h.aiDifficulty = handler.aiDifficulty
-		}
-		board := GetBoard(&h)
-		ui := SetupGameGraphics(board, &h)
-		win.SetContent(ui)
+	// Totals of normal and lives games, only for the games that count toward them
+	statsButton := widget.NewButton("Stats", func() {
+		showGameStats(fyne.CurrentApp().Driver().AllWindows()[0])
 	})
+	if gameKind(handler) == "" {
+		statsButton.Hide()
+	}
 
-	titleScreenButton = widget.NewButton("Title Screen", func() {
+	// The replay viewer's Back button comes back to this finished game (infinite games have no replay)
+	replayButton := widget.NewButton("Watch Replay", func() {
 		win := fyne.CurrentApp().Driver().AllWindows()[0]
-		LoadSetupInto(win)
+		game := win.Content()
+		showReplay(win, replayOf(handler), func() { win.SetContent(game) })
 	})
+	if handler.infinite != nil {
+		replayButton.Hide()
+	}
 
-	gameOverContainer = container.NewVBox(
-		gameMsg,
+	screen.gameOver = container.NewVBox(
+		screen.message,
 		container.NewHBox(
 			newGameButton,
+			dailyButton,
+			puzzlesButton,
+			statsButton,
+			replayButton,
 			titleScreenButton,
 		),
 	)
-	// center over the whole board (headers + grid)
-	totalPx := float32(config.GridSpacing * (config.BoardSize + 1))
-	ms := gameOverContainer.MinSize()
-	gameOverContainer.Move(fyne.NewPos((totalPx-ms.Width)/2, (totalPx-ms.Height)/2))
-	gameOverContainer.Hide()
+	screen.gameOver.Hide()
+
+	// Between hot-seat turns the board waits for the next player to press this
+	screen.handover = widget.NewButton("", func() {
+		handler.startTurn()
+		screen.update()
+		screen.announce(turnText(handler))
+	})
+	screen.handover.Importance = widget.HighImportance
+	screen.handover.Hide()
+
+	// Zoom and save buttons above the board
+	board := screen.board
+	saveButton := widget.NewButton("Save", func() { saveGameAs(handler) })
+	exportButton := widget.NewButton("Export Board", func() { exportBoardAs(handler) })
+	if handler.gridName() != config.GridSquare || handler.cellMines > 1 {
+		exportButton.Disable() // Layouts are always square boards with one mine per cell
+	}
+	if handler.infinite != nil {
+		saveButton.Disable() // Only the part in view would be saved
+		exportButton.Disable()
+	}
 
-	objects = append(objects, gameOverContainer)
+	// Arrows that move the window over the infinite board a quarter of its width at a time, hidden for other games
+	step := config.InfiniteView / 4
+	scrollBy := func(rows, cols int) func() {
+		return func() {
+			handler.scrollView(rows, cols)
+			screen.update()
+		}
+	}
+	scrollButtons := container.NewHBox(
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), scrollBy(0, -step)),
+		widget.NewButtonWithIcon("", theme.MoveUpIcon(), scrollBy(-step, 0)),
+		widget.NewButtonWithIcon("", theme.MoveDownIcon(), scrollBy(step, 0)),
+		widget.NewButtonWithIcon("", theme.NavigateNextIcon(), scrollBy(0, step)),
+	)
+	if handler.infinite == nil {
+		scrollButtons.Hide()
+	}
 
-	// Call to apply overlay states as now that the object itself is "fleshed out" we can actually display it
-	applyOverlayStates(board)
+	// Hot-seat scoreboard, each name next to a square of the colour their cells are tinted with
+	scoreboard := container.NewHBox()
+	screen.scoreboard = scoreboard
+	for i := range screen.players {
+		swatch := canvas.NewRectangle(playerColours[i])
+		swatch.SetMinSize(fyne.NewSize(16, 16))
+		screen.players[i] = widget.NewLabel("")
+		scoreboard.Add(container.NewCenter(swatch))
+		scoreboard.Add(screen.players[i])
+	}
+	if handler.hotseat == nil {
+		scoreboard.Hide()
+	}
+	screen.lives = widget.NewLabel(livesText(handler))
+	screen.lives.TextStyle.Bold = true
+	if handler.startLives <= 1 {
+		screen.lives.Hide()
+	}
+	screen.score = widget.NewLabel(scoreText(handler))
+	screen.score.TextStyle.Bold = true
+	if !handler.scored() {
+		screen.score.Hide()
+	}
+	zoomBar := container.NewHBox(
+		widget.NewButton("Zoom -", func() { board.zoomBy(1 / zoomStep) }),
+		widget.NewButton("Zoom +", func() { board.zoomBy(zoomStep) }),
+		saveButton,
+		exportButton,
+		scrollButtons,
+		screen.lives,
+		screen.score,
+		scoreboard,
+	)
 
-	return container.NewWithoutLayout(objects...)
+	content := container.NewBorder(zoomBar, screen.announcement, nil, nil,
+		container.NewStack(board.inScroll(), container.NewCenter(container.NewVBox(screen.gameOver, screen.handover))))
+	board.attachKeys(content)
+	if handler.timeLimit > 0 {
+		go screen.runClock(content)
+	}
+	return content
 }
 
 /*
-Used as a cell refresher, all this does is go back over all the cells in the cell and check state and apply correct cell properties according to it
-Inputs: 2D-Array of the boards cells
-Outputs: None, just refreshing the underlying values
+Counts down a Time Attack game (scoremode.go): a few times a second the time left is shown again and the game ends once
+it has run out. It stops when the game is over or the window shows something else (the game was left)
+Inputs: The game screen's content
+Outputs: None
 */
-func applyOverlayStates(board [][]Square) {
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
-			ov := cellOverlays[r][c]
-			fl := cellFlags[r][c]
-			switch board[r][c].state {
-			case Covered:
-				ov.FillColor = color.NRGBA{R: 60, G: 60, B: 60, A: 255}
-				ov.Refresh()
-				fl.Hide()
-			case Uncovered:
-				ov.FillColor = color.NRGBA{R: 60, G: 60, B: 60, A: 0}
-				ov.Refresh()
-				fl.Hide()
-			case Flagged:
-				ov.FillColor = color.NRGBA{R: 60, G: 60, B: 60, A: 255}
-				ov.Refresh()
-				fl.Show()
+func (screen *gameScreen) runClock(content fyne.CanvasObject) {
+	ticker := time.NewTicker(clockTick)
+	defer ticker.Stop()
+	for range ticker.C {
+		done := false
+		fyne.DoAndWait(func() {
+			if fyne.CurrentApp().Driver().AllWindows()[0].Content() != content {
+				done = true
+				return
 			}
+			h := screen.handler
+			h.checkClock()
+			screen.update()
+			done = h.gameOver
+		})
+		if done {
+			return
 		}
 	}
 }
 
 /*
-Used mainly as a verifier upon the case that cell text values changed, this specifically happens if needing to regenerate the board due to your first click being a bomb
-Inputs: 2D-Array of the boards cells
-Outputs: None, just refreshing the underlying values
+Shows a game in the window, skipping the title and setup screens
+Inputs: the fyne window itself and the game handler to play
+Outputs: None, replaces the window content
 */
-func updateCellTexts(board [][]Square) {
-	for r := 0; r < config.BoardSize; r++ {
-		for c := 0; c < config.BoardSize; c++ {
-			t := cellTexts[r][c]
-			if t == nil {
-				continue
-			}
+func ShowGame(win fyne.Window, h *Gamehandler) {
+	win.SetContent(SetupGameGraphics(GetBoard(h), h))
+}
 
-			// decide what to show
-			var txt string
-			if board[r][c].isBomb {
-				txt = "b"
-			} else if board[r][c].numValue != 0 {
-				txt = strconv.Itoa(board[r][c].numValue)
-			} else {
-				txt = "" // empty for zeros
-			}
+/*
+Asks for a file name and saves the game there, it can be continued later with --load (or "load" in the REPL)
+Inputs: Game handler of the game being played
+Outputs: None, shows an error dialog if saving failed
+*/
+func saveGameAs(handler *Gamehandler) {
+	win := fyne.CurrentApp().Driver().AllWindows()[0]
+	dialog.ShowFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if file == nil {
+			return // Cancelled
+		}
+		path := file.URI().Path()
+		file.Close()
+		if err := SaveGame(handler, path); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+}
 
-			// update text and keep it centered
-			if t.Text != txt {
-				t.Text = txt
-				t.TextSize = config.GridSpacing / 2
-				sz := t.MinSize()
-				cell := float32(config.GridSpacing)
-				// +1,+1 because the board is offset by headers
-				x := float32((c+1)*config.GridSpacing) + (cell-sz.Width)/2
-				y := float32((r+1)*config.GridSpacing) + (cell-sz.Height)/2
-				t.Move(fyne.NewPos(x, y))
-				t.Refresh()
-			}
-			if board[r][c].markedByAI {
-				t.Color = color.RGBA{255, 255, 0, 255} // Yellow
-			}
-			t.Refresh()
+/*
+Asks for a file name and writes the mines of the board there as a layout grid, it can be played again with --load or
+Open on the title screen
+Inputs: Game handler of the game being played
+Outputs: None, shows an error dialog if writing failed
+*/
+func exportBoardAs(handler *Gamehandler) {
+	win := fyne.CurrentApp().Driver().AllWindows()[0]
+	dialog.ShowFileSave(func(file fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
 		}
+		if file == nil {
+			return // Cancelled
+		}
+		path := file.URI().Path()
+		file.Close()
+		if err := SaveLayout(handler, path, false); err != nil {
+			dialog.ShowError(err, win)
+		}
+	}, win)
+}
+
+/*
+Shows the totals of normal and lives games (stats.go) in a dialog
+Inputs: the fyne window to show it in
+Outputs: None, shows an error dialog if the stats could not be read
+*/
+func showGameStats(win fyne.Window) {
+	stats, err := LoadStats()
+	if err != nil {
+		dialog.ShowError(err, win)
+		return
 	}
+	dialog.ShowInformation("Stats", strings.Join(statsSummary(stats), "\n"), win)
 }
 
 /*
-Inputs: Game handler object for the context
+Starts a new game with the same mine count and mode as the one being played and swaps it into the window, the daily
+challenge goes to its screen instead since it only has one attempt
+Inputs: Game handler of the game being replaced
+Outputs: None, replaces the window content
+*/
+func restartGame(handler *Gamehandler) {
+	win := fyne.CurrentApp().Driver().AllWindows()[0]
+	if handler.daily != "" {
+		showDaily(win)
+		return
+	}
+	h := handler.rematch()
+	ShowGame(win, &h)
+}
+
+/*
+Refreshes the board (only the cells that changed get redrawn) and shows/hides the end of game message
+Inputs: None, uses the screen's game handler
 Outputs: None, just refreshes UI/Shows win condition to screen
 */
-func UpdateGameUI(h *Gamehandler) {
-	updateCellTexts(h.board)
-	applyOverlayStates(h.board)
+func (screen *gameScreen) update() {
+	h := screen.handler
+	screen.board.Refresh()
+	if text := livesText(h); text != screen.lives.Text {
+		screen.lives.SetText(text)
+	}
+	if text := scoreText(h); text != screen.score.Text {
+		screen.score.SetText(text)
+	}
+	if h.hotseat != nil {
+		for i, label := range screen.players {
+			bold := i == h.hotseat.turn && !h.gameOver
+			if text := playerText(h, i); text != label.Text || bold != label.TextStyle.Bold {
+				label.Text, label.TextStyle.Bold = text, bold
+				label.Refresh()
+				screen.scoreboard.Refresh() // Lays the lines out again for their new width
+			}
+		}
+		if h.waitingForPlayer() {
+			screen.handover.SetText(turnText(h) + ", start")
+			screen.handover.Show()
+		} else {
+			screen.handover.Hide()
+		}
+	}
 	if h.gameOver { //play again + title button
-		if h.win {
-			gameMsg.Text = "You Win!"
-			gameMsg.TextStyle.Bold = true
-			gameMsg.Color = color.RGBA{R: 255, G: 222, B: 33, A: 255}
+		if h.hotseat != nil {
+			screen.message.Text = turnText(h)
+			screen.message.Color = color.RGBA{R: 255, G: 222, B: 33, A: 255}
+			if winner := h.hotseatWinner(); winner >= 0 {
+				screen.message.Color = playerColours[winner]
+			}
+		} else if h.limitReached {
+			screen.message.Text = "Time's Up!"
+			if h.timeLimit == 0 {
+				screen.message.Text = "Out of Moves!"
+			}
+			screen.message.Color = color.RGBA{R: 255, G: 160, A: 255}
+		} else if h.win && h.puzzle != nil {
+			screen.message.Text = "Solved!"
+			screen.message.Color = color.RGBA{R: 255, G: 222, B: 33, A: 255}
+		} else if h.win {
+			screen.message.Text = "You Win!"
+			screen.message.Color = color.RGBA{R: 255, G: 222, B: 33, A: 255}
 		} else {
-			gameMsg.Text = "Game Over"
-			gameMsg.TextStyle.Bold = true
-			gameMsg.Color = color.RGBA{R: 220, A: 255}
+			screen.message.Text = "Game Over"
+			screen.message.Color = color.RGBA{R: 220, A: 255}
+		}
+		screen.message.Refresh()
+		screen.gameOver.Show()
+		if !screen.announced {
+			screen.announced = true
+			screen.announce(describeResult(h))
+			if h.infinite == nil { // An infinite board has no replay, its moves were made on a window that moved
+				if _, err := SaveReplay(replayOf(h)); err != nil {
+					fmt.Println("could not save the replay:", err)
+				}
+			}
+			if h.daily != "" {
+				if err := recordDaily(h); err != nil {
+					fmt.Println("could not save the daily result:", err)
+				}
+			}
+			if h.puzzle != nil && h.win {
+				if err := recordPuzzleSolved(h); err != nil {
+					fmt.Println("could not save the puzzle progress:", err)
+				}
+			}
+			if err := recordGame(h); err != nil {
+				fmt.Println("could not save the game stats:", err)
+			}
 		}
-		gameMsg.Refresh()
+	} else {
+		screen.gameOver.Hide()
+		screen.announced = false // Undo can take the game back from being over
+	}
+}
 
-		totalPx := float32(config.GridSpacing * (config.BoardSize + 1))
-		ms := gameOverContainer.MinSize()
-		gameOverContainer.Move(fyne.NewPos((totalPx-ms.Width)/2, (totalPx-ms.Height)/2))
+/*
+Shows a description under the board and speaks it if speech is turned on, why a puzzle move was refused goes first
+Inputs: Description of what changed
+Outputs: None
+*/
+func (screen *gameScreen) announce(text string) {
+	if p := screen.handler.puzzle; p != nil && p.message != "" {
+		text, p.message = p.message, ""
+	}
+	if text == "" || text == screen.announcement.Text {
+		return
+	}
+	screen.announcement.SetText(text)
+	speak(text)
+}
 
-		gameOverContainer.Show()
-		gameOverContainer.Refresh()
-	} else {
-		gameOverContainer.Hide()
+/*
+Inputs: Game handler object for the context
+Outputs: None, has the screen showing this game refresh itself/Show the win condition
+*/
+func UpdateGameUI(h *Gamehandler) {
+	if h.onChange != nil {
+		h.onChange()
 	}
 }
@@ -7,7 +7,8 @@ Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
 Creation Date: 9/11/2025
 
 Description:
-- This file hosts some basic config file bits to be called inside the go files instead of relying on magic numbers
+- This file hosts some basic config file bits to be called inside the go files instead of relying on magic numbers.
+These are the defaults, the player's own choices are kept in the settings file (settings.go) and read through Current
 
 Functions:
 - None: Constant containing bunch of variables to be called
@@ -23,9 +24,11 @@ Outputs:
 const (
 	BoardSize    = 10 // Used to declare how many mines in the board
 	MinMines     = 10 // Used to decide/display minimum allowed mines
-	MaxMines     = 20 // Used to decide/display maximum allowed mines
 	WindowHeight = 500 // Used to declare the window borders
 	WindowWidth  = 500 // Used to declare window borders
-	FixedWinSize = true // Bool to disallow adjusting window size
-	GridSpacing  = (WindowHeight) / (BoardSize + 1) // Used to adjust how much space in the grid
+	FixedWinSize = false // Bool to disallow adjusting window size (the board scales with the window now)
+	MinCellSize  = 24 // Smallest a board cell is drawn before the board scrolls instead of shrinking
+	MinBoardSize = 5 // Smallest number of rows/columns the settings allow
+	MaxBoardSize = 30 // Largest number of rows/columns the settings allow
+	AIDelayMs    = 1000 // Pause between solver moves in milliseconds
 )
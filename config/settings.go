@@ -0,0 +1,340 @@
+package config
+
+/*
+Prologue
+
+Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
+Creation Date: 10/19/2026
+
+Description:
+- This file holds the settings the player can change from the Settings screen. They are saved as JSON in the user's
+config directory ($XDG_CONFIG_HOME/minesweeper/settings.json on Linux) so they are kept between runs. The constants in
+constants.go are only the defaults for when there is no settings file or a value in it is missing/out of range
+
+Functions:
+- DefaultSettings: The settings used when there is no settings file yet
+
+- defaultPlayerName: The daily challenge name used until the player picks one (their login name)
+
+- SettingsPath: Where the settings file lives
+
+- LoadSettings: Reads the settings file into Current
+
+- SaveSettings: Writes Current to the settings file
+
+- MineLimits: The smallest and largest mine count allowed for a board size
+
+- PresetNamed: Finds a difficulty preset by name
+
+- SafeZone: How many cells a first click policy keeps free of mines
+
+- DensityMines/MineDensity: Convert between a mine density percentage and a mine count
+
+- clean: Puts any missing or out of range value back to its default
+
+Inputs:
+- The settings file
+
+Outputs:
+- Current settings for the rest of the game to read
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// First click policies, what happens when the first cell clicked is a mine
+const (
+	FirstClickSafe    = "safe"    // The mine is moved away so the first click never loses
+	FirstClickOpening = "opening" // The clicked cell and all its neighbours are kept clear so the first click opens an area
+	FirstClickNone    = "none"    // Nothing is moved, the first click can lose
+)
+
+// Board grids, which cells touch (see components/topology.go)
+const (
+	GridSquare  = "square"  // Classic squares, 8 neighbours
+	GridHex     = "hex"     // Hexagons in offset rows, 6 neighbours
+	GridTorus   = "torus"   // Squares with the edges joined (wrap-around), every cell has 8 neighbours
+	GridKnight  = "knight"  // Squares, the neighbours are the 8 cells a chess knight's move away
+	GridRadius2 = "radius2" // Squares, the neighbours are the 24 cells within two steps (a 5x5 block)
+	GridCross   = "cross"   // Squares, only the 4 cells sharing a side are neighbours
+)
+
+// The grids in the order the mine setup screen lists them
+var Grids = []string{GridSquare, GridHex, GridTorus, GridKnight, GridRadius2, GridCross}
+
+// Most mines one cell can hold, a cell with more than one needs that many flags (see components/multimine.go)
+const MaxCellMines = 3
+
+// Most lives a game can start with, 1 is the classic game where the first mine ends it (see components/lives.go)
+const MaxLives = 5
+
+// Countdowns offered for Time Attack games in seconds and budgets offered for Move Budget games, the middle one is
+// what --mode timed/moves use without --limit (see components/scoremode.go)
+var (
+	TimeLimits = []int{60, 120, 300}
+	MoveLimits = []int{25, 50, 100}
+)
+
+// Longest countdown (seconds) and biggest move budget --limit takes
+const (
+	MaxTimeLimit = 3600
+	MaxMoveLimit = 1000
+)
+
+// The infinite board (see components/infinite.go) is made of square chunks that all have the same number of mines, the
+// window shows one part of it at a time
+const (
+	InfiniteChunk = 16 // Rows and columns of a chunk
+	InfiniteMines = 40 // Mines in each chunk, about 16% of its cells
+	InfiniteView  = 16 // Rows and columns of the part that is shown
+)
+
+// Longest name a hot-seat player can have (see components/hotseat.go)
+const MaxNameLength = 16
+
+// Difficulty presets offered on the mine setup screen, Custom means the board size and mines were picked by hand
+const (
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyExpert       = "expert"
+	DifficultyCustom       = "custom"
+)
+
+// Preset is a board size and mine count that can be picked with one button
+type Preset struct {
+	Name  string // One of the Difficulty names
+	Label string // Shown on the button
+	Rows  int
+	Cols  int
+	Mines int
+}
+
+// The classic difficulties, in the order they are shown
+var Presets = []Preset{
+	{DifficultyBeginner, "Beginner", 9, 9, 10},
+	{DifficultyIntermediate, "Intermediate", 16, 16, 40},
+	{DifficultyExpert, "Expert", 16, 30, 99},
+}
+
+// Settings is everything stored in the settings file
+type Settings struct {
+	Rows          int                 `json:"rows"`           // Board height
+	Cols          int                 `json:"cols"`           // Board width
+	Mines         int                 `json:"mines"`          // Mine count the setup screens start with
+	Difficulty    string              `json:"difficulty"`     // Last difficulty picked on the mine setup screen
+	Theme         string              `json:"theme"`          // Board theme name, see components/theme.go
+	Palette       string              `json:"palette"`        // Colour-blind palette name, see components/accessibility.go
+	AIMarkers     bool                `json:"ai_markers"`     // Mark cells the AI revealed with a shape as well as a colour
+	Speak         bool                `json:"speak"`          // Read cell descriptions and the result out loud
+	AIDelayMs     int                 `json:"ai_delay_ms"`    // Pause between solver moves
+	FirstClick    string              `json:"first_click"`    // One of the FirstClick policies
+	QuestionMarks bool                `json:"question_marks"` // Right click goes flag -> question mark -> covered
+	Keys          map[string][]string `json:"keys"`           // Action name to key names, only the actions that were changed
+	WindowWidth   int                 `json:"window_width"`   // Size the window opens at
+	WindowHeight  int                 `json:"window_height"`  // Size the window opens at
+	FixedWindow   bool                `json:"fixed_window"`   // Stop the window from being resized
+	PlayerName    string              `json:"player_name"`    // Name results are saved under on the daily challenge leaderboard
+	Grid          string              `json:"grid"`           // One of the Grid names, the grid new games are played on
+	CellMines     int                 `json:"cell_mines"`     // Most mines a cell of a new game can hold, 1 is the classic game
+	Lives         int                 `json:"lives"`          // Lives a new game starts with, 1 is the classic game
+	HotseatNames  []string            `json:"hotseat_names"`  // Names the two hot-seat players used last time
+}
+
+// Current settings, LoadSettings fills these in at startup
+var Current = DefaultSettings()
+
+// Gives the settings used before anything has been saved
+// Inputs: None
+// Outputs: Default Settings
+func DefaultSettings() Settings {
+	return Settings{
+		Rows:         BoardSize,
+		Cols:         BoardSize,
+		Mines:        MinMines,
+		Difficulty:   DifficultyCustom,
+		Theme:        "classic",
+		Palette:      "theme",
+		AIDelayMs:    AIDelayMs,
+		FirstClick:   FirstClickSafe,
+		WindowWidth:  WindowWidth,
+		WindowHeight: WindowHeight,
+		FixedWindow:  FixedWinSize,
+		PlayerName:   defaultPlayerName(),
+		Grid:         GridSquare,
+		CellMines:    1,
+		Lives:        1,
+		HotseatNames: []string{"Player 1", "Player 2"},
+	}
+}
+
+// Gives the name the daily challenge results are saved under until the player picks one, their login name if it is
+// known
+// Inputs: None
+// Outputs: Player name
+func defaultPlayerName() string {
+	for _, key := range []string{"USER", "USERNAME"} {
+		if name := strings.TrimSpace(os.Getenv(key)); name != "" {
+			return name
+		}
+	}
+	return "Player"
+}
+
+// Gives the path of the settings file inside the user's config directory
+// Inputs: None
+// Outputs: Path to settings.json, or an error if there is no config directory (e.g. $HOME not set)
+func SettingsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "minesweeper", "settings.json"), nil
+}
+
+// Reads the settings file into Current, anything missing from the file keeps its default
+// Inputs: None
+// Outputs: Error if the file exists but could not be read, a missing file is not an error
+func LoadSettings() error {
+	path, err := SettingsPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	loaded := DefaultSettings()
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return err
+	}
+	loaded.clean()
+	Current = loaded
+	return nil
+}
+
+// Writes Current to the settings file, creating the directory if needed
+// Inputs: None
+// Outputs: Error if the file could not be written
+func SaveSettings() error {
+	path, err := SettingsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(Current, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Gives the mine counts allowed for a board size. The smallest is MinMines scaled by area from the default board, the
+// largest is (rows-1)*(cols-1) like the classic game so the board can never be all mines
+// Inputs: Board rows and columns
+// Outputs: Smallest and largest allowed mine count
+func MineLimits(rows int, cols int) (int, int) {
+	cells := rows * cols
+	defaultCells := BoardSize * BoardSize
+	lo := max(1, MinMines*cells/defaultCells)
+	hi := min(cells-1, (rows-1)*(cols-1))
+	return lo, max(lo, hi)
+}
+
+// Gives how many cells the first click keeps free of mines, a board needs at least that many cells without a mine
+// Inputs: One of the FirstClick policies and the most neighbours a cell has on the grid being played
+// Outputs: Number of cells
+func SafeZone(policy string, neighbours int) int {
+	switch policy {
+	case FirstClickOpening:
+		return neighbours + 1 // The clicked cell and its neighbours
+	case FirstClickNone:
+		return 0
+	}
+	return 1
+}
+
+// Gives the mine count for a density, rounded to the nearest whole mine
+// Inputs: Board rows and columns, density as a percentage of the cells
+// Outputs: Mine count
+func DensityMines(rows int, cols int, percent int) int {
+	return (rows*cols*percent + 50) / 100
+}
+
+// Gives the density of a mine count, rounded to the nearest whole percent (the reverse of DensityMines)
+// Inputs: Board rows and columns, mine count
+// Outputs: Density as a percentage of the cells
+func MineDensity(rows int, cols int, mines int) int {
+	cells := max(rows*cols, 1)
+	return (mines*100 + cells/2) / cells
+}
+
+// Finds a difficulty preset
+// Inputs: Preset name (one of the Difficulty names)
+// Outputs: The preset, false for Custom or an unknown name
+func PresetNamed(name string) (Preset, bool) {
+	for _, p := range Presets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+// Puts any value that is missing or out of range (e.g. a hand edited file) back to its default
+// Inputs: None
+// Outputs: None, changes the settings
+func (s *Settings) clean() {
+	def := DefaultSettings()
+	if s.Rows < MinBoardSize || s.Rows > MaxBoardSize || s.Cols < MinBoardSize || s.Cols > MaxBoardSize {
+		s.Rows, s.Cols = def.Rows, def.Cols
+	}
+	lo, hi := MineLimits(s.Rows, s.Cols)
+	s.Mines = min(max(s.Mines, lo), hi)
+	// A preset whose board was changed by hand (e.g. on the Settings screen) is Custom now
+	if p, ok := PresetNamed(s.Difficulty); !ok || p.Rows != s.Rows || p.Cols != s.Cols || p.Mines != s.Mines {
+		s.Difficulty = DifficultyCustom
+	}
+	if s.AIDelayMs < 0 {
+		s.AIDelayMs = def.AIDelayMs
+	}
+	if s.FirstClick != FirstClickSafe && s.FirstClick != FirstClickOpening && s.FirstClick != FirstClickNone {
+		s.FirstClick = def.FirstClick
+	}
+	if !slices.Contains(Grids, s.Grid) {
+		s.Grid = def.Grid
+	}
+	if s.CellMines < 1 || s.CellMines > MaxCellMines {
+		s.CellMines = def.CellMines
+	}
+	if s.Lives < 1 || s.Lives > MaxLives {
+		s.Lives = def.Lives
+	}
+	if s.WindowWidth <= 0 || s.WindowHeight <= 0 {
+		s.WindowWidth, s.WindowHeight = def.WindowWidth, def.WindowHeight
+	}
+	s.PlayerName = strings.TrimSpace(s.PlayerName)
+	if s.PlayerName == "" {
+		s.PlayerName = def.PlayerName
+	}
+	if len(s.HotseatNames) != len(def.HotseatNames) {
+		s.HotseatNames = def.HotseatNames
+	}
+	for i, name := range s.HotseatNames {
+		if name = strings.TrimSpace(name); name == "" || len([]rune(name)) > MaxNameLength {
+			name = def.HotseatNames[i]
+		}
+		s.HotseatNames[i] = name
+	}
+}
@@ -3,12 +3,19 @@ Authors: Adam Berry, Barrett Brown, Jonathan Gott, Alex Phibbs, Minh Vu
 Creation Date: 9/11/2025
 
 Description: Initializes everything and especially the Fyne app. Sets up the main window and loads
-the setup screen.
+the setup screen. Running with --tui skips the Fyne app entirely and plays in the terminal instead, --repl
+plays by typed commands instead. The settings file is loaded first so every front-end uses the saved settings.
+The game flags (--mode, --ai, --size, --mines, --seed, --load) skip the title and setup screens and start that game
+straight away in whichever front-end is used, see components/launch.go.
 */
 
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"minesweeper/components"
 	"minesweeper/config"
 
@@ -19,11 +26,66 @@ import (
 //var numberOfMines int = 10   // User Determined, can be 10 or 20
 
 func main() {
+	tui := flag.Bool("tui", false, "play in the terminal instead of opening a window (works over SSH)")
+	repl := flag.Bool("repl", false, "play by typing moves like \"r c4\" (reads from stdin so a file of moves can be piped in)")
+	var launch components.LaunchOptions
+	flag.StringVar(&launch.Mode, "mode", "", "start a game straight away: single, ai (1v1 against the AI), solver, timed (clear as many cells as possible before the time runs out), moves (the same with a budget of reveals), infinite (a board with no edges) or hotseat (two players taking turns, named on the Hot Seat screen)")
+	flag.StringVar(&launch.AI, "ai", "", "AI difficulty for --mode ai/solver: easy, medium or hard")
+	flag.StringVar(&launch.Size, "size", "", "board size as WIDTHxHEIGHT, e.g. 16x16")
+	flag.IntVar(&launch.Mines, "mines", 0, "number of mines")
+	flag.Int64Var(&launch.Seed, "seed", 0, "seed for the mine layout, the same seed gives the same board")
+	flag.StringVar(&launch.Grid, "grid", "", "board grid / neighbour rule: square (8 neighbours), hex (6), torus (square with the edges wrapping around), knight (knight's moves), radius2 (24 within two steps) or cross (4, no diagonals)")
+	flag.IntVar(&launch.CellMines, "cell-mines", 0, "most mines one cell can hold (1 to 3), a cell with several needs that many flags")
+	flag.IntVar(&launch.Lives, "lives", 0, "lives to start with (1 to 5), hitting a mine costs one and the game goes on until they run out")
+	flag.IntVar(&launch.Limit, "limit", 0, "seconds for --mode timed or moves for --mode moves (default 120 seconds or 50 moves)")
+	flag.StringVar(&launch.Load, "load", "", "continue a game saved with the Save button or the REPL's save command, or play a board layout or .rawvf file")
+	flag.Parse()
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			launch.Seeded = true
+		}
+	})
+
+	if err := config.LoadSettings(); err != nil {
+		fmt.Fprintln(os.Stderr, "could not load settings, using the defaults:", err)
+	}
+
+	if *repl {
+		if err := components.RunREPL(os.Stdin, os.Stdout, launch); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *tui {
+		if err := components.RunTUI(launch); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Check the game flags before opening a window so a mistake is reported on the command line
+	var game components.Gamehandler
+	if launch.Wanted() {
+		var err error
+		if game, err = launch.NewGame(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
 	a := app.New()
+	a.Settings().SetTheme(components.AppTheme())
 	window := a.NewWindow("Minesweeper")
-	window.Resize(fyne.NewSize(config.WindowHeight, config.WindowWidth))
-	window.SetFixedSize(config.FixedWinSize)
+	window.Resize(fyne.NewSize(float32(config.Current.WindowWidth), float32(config.Current.WindowHeight)))
+	window.SetFixedSize(config.Current.FixedWindow)
 
-	components.LoadSetupInto(window)
+	if launch.Wanted() {
+		components.ShowGame(window, &game)
+	} else {
+		components.LoadSetupInto(window)
+	}
 	window.ShowAndRun()
 }